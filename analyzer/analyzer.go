@@ -0,0 +1,538 @@
+// Package analyzer defines an Analyzer that reports unsafe usage of
+// optional.Val:
+//
+//   - calls of Val() which are not guarded by a presence check, because
+//     Val() silently returns the zero value of an empty Val;
+//   - calls of Get() where the ok result is discarded;
+//   - comparisons of Val values with == and !=.
+//
+// A call of x.Val() is guarded when it is evaluated only after x.HasVal() is
+// known to be true and x is not assigned or reset in between:
+//
+//	if x.HasVal() { use(x.Val()) }
+//	if !x.HasVal() { return }; use(x.Val())
+//	if !x.HasVal() { x = optional.New(1) }; use(x.Val())
+//	ok := x.HasVal() && x.Val() > 0
+//	require.True(t, x.HasVal()); use(x.Val())
+//	if assert.True(t, x.HasVal()) { use(x.Val()) }
+//
+// Assertions are recognized for the require and assert packages of testify.
+//
+// Suggested fixes replace unguarded calls with ValDefault.
+//
+// The analyzer can be used with go vet through cmd/optional-vet and with
+// golangci-lint through the module plugin in package analyzer/golangci.
+package analyzer
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"slices"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+const (
+	optionalPath = "github.com/kazhuravlev/optional"
+	requirePath  = "github.com/stretchr/testify/require"
+	assertPath   = "github.com/stretchr/testify/assert"
+)
+
+const doc = `check usage of optional.Val
+
+Reports calls of Val() without a preceding presence check, calls of Get()
+with discarded ok result and comparisons of optional.Val with == and !=.`
+
+// Analyzer reports unsafe usage of optional.Val.
+var Analyzer = &analysis.Analyzer{ //nolint:exhaustruct
+	Name:     "optional",
+	Doc:      doc,
+	URL:      "https://pkg.go.dev/github.com/kazhuravlev/optional/analyzer",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	if pass.Pkg.Path() == optionalPath {
+		return nil, nil //nolint:nilnil
+	}
+
+	insp := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector) //nolint:forcetypeassert
+
+	filter := []ast.Node{
+		(*ast.File)(nil),
+		(*ast.CallExpr)(nil),
+		(*ast.AssignStmt)(nil),
+		(*ast.ValueSpec)(nil),
+		(*ast.BinaryExpr)(nil),
+	}
+
+	var file *ast.File
+
+	insp.WithStack(filter, func(node ast.Node, push bool, stack []ast.Node) bool {
+		if !push {
+			return true
+		}
+
+		switch node := node.(type) {
+		case *ast.File:
+			file = node
+		case *ast.CallExpr:
+			checkVal(pass, file, node, stack)
+		case *ast.AssignStmt:
+			if len(node.Lhs) == 2 && len(node.Rhs) == 1 {
+				checkGet(pass, file, node, node.Lhs, node.Rhs[0])
+			}
+		case *ast.ValueSpec:
+			if len(node.Names) == 2 && len(node.Values) == 1 {
+				checkGet(pass, file, node, []ast.Expr{node.Names[0], node.Names[1]}, node.Values[0])
+			}
+		case *ast.BinaryExpr:
+			checkCompare(pass, node)
+		}
+
+		return true
+	})
+
+	return nil, nil //nolint:nilnil
+}
+
+// checkVal reports call of Val() which is not guarded by a presence check.
+func checkVal(pass *analysis.Pass, file *ast.File, call *ast.CallExpr, stack []ast.Node) {
+	recv, ok := valMethodCall(pass, call, "Val")
+	if !ok || isGuarded(pass, recv, stack) {
+		return
+	}
+
+	diag := analysis.Diagnostic{ //nolint:exhaustruct
+		Pos:     call.Pos(),
+		End:     call.End(),
+		Message: "Val() is called without presence check: check HasVal(), use Get() or ValDefault()",
+	}
+
+	sel, _ := ast.Unparen(call.Fun).(*ast.SelectorExpr) // checked by valMethodCall
+	if zero, ok := zeroValue(pass, file, pass.TypesInfo.TypeOf(call)); ok {
+		diag.SuggestedFixes = []analysis.SuggestedFix{{
+			Message: "Replace with ValDefault(" + zero + ")",
+			TextEdits: []analysis.TextEdit{
+				{Pos: sel.Sel.Pos(), End: sel.Sel.End(), NewText: []byte("ValDefault")},
+				{Pos: call.Lparen, End: call.Rparen + 1, NewText: []byte("(" + zero + ")")},
+			},
+		}}
+	}
+
+	pass.Report(diag)
+}
+
+// checkGet reports `val, _ := x.Get()`.
+func checkGet(pass *analysis.Pass, file *ast.File, node ast.Node, lhs []ast.Expr, rhs ast.Expr) {
+	call, ok := ast.Unparen(rhs).(*ast.CallExpr)
+	if !ok {
+		return
+	}
+
+	recv, ok := valMethodCall(pass, call, "Get")
+	if !ok || !isBlank(lhs[1]) {
+		return
+	}
+
+	diag := analysis.Diagnostic{ //nolint:exhaustruct
+		Pos:     node.Pos(),
+		End:     node.End(),
+		Message: "ok result of Get() is discarded: check it or use ValDefault()",
+	}
+
+	typ, _ := pass.TypesInfo.TypeOf(call).(*types.Tuple)
+	if typ != nil && !isBlank(lhs[0]) {
+		if zero, ok := zeroValue(pass, file, typ.At(0).Type()); ok {
+			diag.SuggestedFixes = []analysis.SuggestedFix{{
+				Message: "Replace with ValDefault(" + zero + ")",
+				TextEdits: []analysis.TextEdit{
+					{Pos: lhs[0].End(), End: lhs[1].End(), NewText: nil},
+					{Pos: call.Pos(), End: call.End(), NewText: []byte(
+						types.ExprString(recv) + ".ValDefault(" + zero + ")",
+					)},
+				},
+			}}
+		}
+	}
+
+	pass.Report(diag)
+}
+
+// checkCompare reports comparison of Val values.
+func checkCompare(pass *analysis.Pass, expr *ast.BinaryExpr) {
+	if expr.Op != token.EQL && expr.Op != token.NEQ {
+		return
+	}
+
+	if !isVal(pass.TypesInfo.TypeOf(expr.X)) && !isVal(pass.TypesInfo.TypeOf(expr.Y)) {
+		return
+	}
+
+	pass.Report(analysis.Diagnostic{ //nolint:exhaustruct
+		Pos:     expr.Pos(),
+		End:     expr.End(),
+		Message: "optional.Val is compared with " + expr.Op.String() + ": compare results of Get() instead",
+	})
+}
+
+// valMethodCall returns the receiver of call when it is a call of the method
+// of optional.Val.
+func valMethodCall(pass *analysis.Pass, call *ast.CallExpr, method string) (ast.Expr, bool) {
+	sel, ok := ast.Unparen(call.Fun).(*ast.SelectorExpr)
+	if !ok || sel.Sel.Name != method {
+		return nil, false
+	}
+
+	selection := pass.TypesInfo.Selections[sel]
+	if selection == nil || selection.Kind() != types.MethodVal {
+		return nil, false
+	}
+
+	recv := selection.Obj().(*types.Func).Signature().Recv() //nolint:forcetypeassert
+	if recv == nil {
+		return nil, false
+	}
+
+	typ := recv.Type()
+	if ptr, ok := types.Unalias(typ).(*types.Pointer); ok {
+		typ = ptr.Elem()
+	}
+
+	if !isVal(typ) {
+		return nil, false
+	}
+
+	return sel.X, true
+}
+
+// isVal reports whether typ is optional.Val or optional.Quoted, which has
+// the same methods.
+func isVal(typ types.Type) bool {
+	named, ok := types.Unalias(typ).(*types.Named)
+	if !ok {
+		return false
+	}
+
+	obj := named.Obj()
+
+	return obj.Pkg() != nil && obj.Pkg().Path() == optionalPath && (obj.Name() == "Val" || obj.Name() == "Quoted")
+}
+
+func isBlank(expr ast.Expr) bool {
+	ident, ok := expr.(*ast.Ident)
+
+	return ok && ident.Name == "_"
+}
+
+// isGuarded reports whether the innermost node of stack is evaluated only
+// when recv has a value.
+func isGuarded(pass *analysis.Pass, recv ast.Expr, stack []ast.Node) bool {
+	key := exprKey(recv)
+
+	for i := len(stack) - 2; i >= 0; i-- {
+		child := stack[i+1]
+
+		switch parent := stack[i].(type) {
+		case *ast.FuncDecl:
+			return false
+		case *ast.BinaryExpr:
+			if child != parent.Y {
+				continue
+			}
+
+			if parent.Op == token.LAND && impliesPresent(pass, parent.X, key) ||
+				parent.Op == token.LOR && impliesAbsent(pass, parent.X, key) {
+				return true
+			}
+		case *ast.IfStmt:
+			if child == parent.Body && impliesPresent(pass, parent.Cond, key) ||
+				child == parent.Else && impliesAbsent(pass, parent.Cond, key) {
+				return true
+			}
+		case *ast.ForStmt:
+			// Assignments in the loop precede the call in the next iteration.
+			if child == parent.Body && (changes(pass, parent.Body, key) || changes(pass, parent.Post, key)) {
+				return false
+			}
+		case *ast.RangeStmt:
+			if child == parent.Body && changes(pass, parent.Body, key) {
+				return false
+			}
+		case *ast.CaseClause:
+			if stmt, ok := child.(ast.Stmt); !ok || !slices.Contains(parent.Body, stmt) {
+				continue
+			}
+
+			if guarded, changed := guardedBefore(pass, parent.Body, child, key); guarded || changed {
+				return guarded
+			}
+
+			for _, expr := range parent.List {
+				if impliesPresent(pass, expr, key) {
+					return true
+				}
+			}
+		case *ast.BlockStmt:
+			if guarded, changed := guardedBefore(pass, parent.List, child, key); guarded || changed {
+				return guarded
+			}
+		case *ast.CommClause:
+			if guarded, changed := guardedBefore(pass, parent.Body, child, key); guarded || changed {
+				return guarded
+			}
+		}
+	}
+
+	return false
+}
+
+// guardedBefore looks for the nearest statement before child which either
+// ensures that the value is present or changes it.
+func guardedBefore(pass *analysis.Pass, stmts []ast.Stmt, child ast.Node, key string) (guarded, changed bool) {
+	idx := slices.IndexFunc(stmts, func(stmt ast.Stmt) bool { return stmt == child })
+	if idx == -1 {
+		return false, false
+	}
+
+	for i := idx - 1; i >= 0; i-- {
+		switch stmt := stmts[i].(type) {
+		case *ast.IfStmt:
+			if stmt.Else == nil && impliesAbsent(pass, stmt.Cond, key) && ensuresPresent(pass, stmt.Body, key) {
+				return true, false
+			}
+		case *ast.ExprStmt:
+			if isAssertion(pass, stmt, key) {
+				return true, false
+			}
+		}
+
+		if changes(pass, stmts[i], key) {
+			return false, true
+		}
+	}
+
+	return false, false
+}
+
+// ensuresPresent reports whether the body of `if !x.HasVal() {}` leaves the
+// function, the loop or assigns a value.
+func ensuresPresent(pass *analysis.Pass, body *ast.BlockStmt, key string) bool {
+	if len(body.List) == 0 {
+		return false
+	}
+
+	switch last := body.List[len(body.List)-1].(type) {
+	case *ast.ReturnStmt, *ast.BranchStmt:
+		return true
+	case *ast.ExprStmt:
+		if call, ok := last.X.(*ast.CallExpr); ok {
+			ident, ok := ast.Unparen(call.Fun).(*ast.Ident)
+			if ok && pass.TypesInfo.Uses[ident] == types.Universe.Lookup("panic") {
+				return true
+			}
+		}
+	}
+
+	for _, stmt := range body.List {
+		switch stmt := stmt.(type) {
+		case *ast.AssignStmt:
+			for _, lhs := range stmt.Lhs {
+				if exprKey(lhs) == key {
+					return true
+				}
+			}
+		case *ast.ExprStmt:
+			call, ok := stmt.X.(*ast.CallExpr)
+			if !ok {
+				continue
+			}
+
+			if recv, ok := valMethodCall(pass, call, "Set"); ok && exprKey(recv) == key {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// changes reports whether node assigns, declares or resets the value, or
+// a variable which holds it.
+func changes(pass *analysis.Pass, node ast.Node, key string) bool {
+	if node == nil {
+		return false
+	}
+
+	changed := false
+	overlaps := func(expr ast.Expr) bool {
+		other := exprKey(expr)
+
+		return other == key || strings.HasPrefix(key, other+".") || strings.HasPrefix(key, other+"[")
+	}
+
+	ast.Inspect(node, func(node ast.Node) bool {
+		switch node := node.(type) {
+		case *ast.AssignStmt:
+			changed = changed || slices.ContainsFunc(node.Lhs, overlaps)
+		case *ast.ValueSpec:
+			for _, name := range node.Names {
+				changed = changed || overlaps(name)
+			}
+		case *ast.RangeStmt:
+			changed = changed || node.Key != nil && overlaps(node.Key) || node.Value != nil && overlaps(node.Value)
+		case *ast.CallExpr:
+			if recv, ok := valMethodCall(pass, node, "Reset"); ok && overlaps(recv) {
+				changed = true
+			}
+		}
+
+		return !changed
+	})
+
+	return changed
+}
+
+// isAssertion reports whether stmt is like `require.True(t, x.HasVal())`.
+func isAssertion(pass *analysis.Pass, stmt *ast.ExprStmt, key string) bool {
+	call, ok := stmt.X.(*ast.CallExpr)
+
+	return ok && isTrueAssertion(pass, call, requirePath, key)
+}
+
+// isTrueAssertion reports whether call is True or Truef of the testify
+// package pkgPath, either a function or a method of Assertions, with
+// an argument which implies that the value is present.
+func isTrueAssertion(pass *analysis.Pass, call *ast.CallExpr, pkgPath, key string) bool {
+	sel, ok := ast.Unparen(call.Fun).(*ast.SelectorExpr)
+	if !ok {
+		return false
+	}
+
+	fn, ok := pass.TypesInfo.Uses[sel.Sel].(*types.Func)
+	if !ok || fn.Pkg() == nil || fn.Pkg().Path() != pkgPath || fn.Name() != "True" && fn.Name() != "Truef" {
+		return false
+	}
+
+	for _, arg := range call.Args {
+		if impliesPresent(pass, arg, key) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// impliesPresent reports whether true cond means that the value is present.
+func impliesPresent(pass *analysis.Pass, cond ast.Expr, key string) bool {
+	switch cond := ast.Unparen(cond).(type) {
+	case *ast.CallExpr:
+		if recv, ok := valMethodCall(pass, cond, "HasVal"); ok {
+			return exprKey(recv) == key
+		}
+
+		return isTrueAssertion(pass, cond, assertPath, key)
+	case *ast.BinaryExpr:
+		return cond.Op == token.LAND && (impliesPresent(pass, cond.X, key) || impliesPresent(pass, cond.Y, key))
+	}
+
+	return false
+}
+
+// impliesAbsent reports whether false cond means that the value is present.
+func impliesAbsent(pass *analysis.Pass, cond ast.Expr, key string) bool {
+	switch cond := ast.Unparen(cond).(type) {
+	case *ast.UnaryExpr:
+		return cond.Op == token.NOT && impliesPresent(pass, cond.X, key)
+	case *ast.BinaryExpr:
+		return cond.Op == token.LOR && (impliesAbsent(pass, cond.X, key) || impliesAbsent(pass, cond.Y, key))
+	}
+
+	return false
+}
+
+// exprKey returns a string which is the same for syntactically equal expressions.
+func exprKey(expr ast.Expr) string {
+	expr = ast.Unparen(expr)
+	if star, ok := expr.(*ast.StarExpr); ok {
+		expr = star.X
+	}
+
+	return types.ExprString(expr)
+}
+
+// zeroValue returns an expression of the zero value of typ, which is valid in
+// file.
+func zeroValue(pass *analysis.Pass, file *ast.File, typ types.Type) (string, bool) {
+	if typ == nil {
+		return "", false
+	}
+
+	if _, ok := types.Unalias(typ).(*types.TypeParam); ok {
+		return "*new(" + typ.String() + ")", true
+	}
+
+	switch under := typ.Underlying().(type) {
+	case *types.Basic:
+		switch {
+		case under.Info()&types.IsBoolean != 0:
+			return "false", true
+		case under.Info()&types.IsString != 0:
+			return `""`, true
+		case under.Info()&types.IsNumeric != 0:
+			return "0", true
+		case under.Kind() == types.UnsafePointer:
+			return "nil", true
+		}
+	case *types.Pointer, *types.Slice, *types.Map, *types.Chan, *types.Signature, *types.Interface:
+		return "nil", true
+	case *types.Struct, *types.Array:
+		name, ok := typeString(pass, file, typ)
+		if !ok {
+			return "", false
+		}
+
+		return name + "{}", true
+	}
+
+	return "", false
+}
+
+// typeString returns typ qualified with names of imports of file.
+func typeString(pass *analysis.Pass, file *ast.File, typ types.Type) (string, bool) {
+	imported := make(map[string]string)
+
+	for _, spec := range file.Imports {
+		path := strings.Trim(spec.Path.Value, `"`)
+
+		switch {
+		case spec.Name == nil:
+			if obj, ok := pass.TypesInfo.Implicits[spec].(*types.PkgName); ok {
+				imported[path] = obj.Name()
+			}
+		case spec.Name.Name != "_" && spec.Name.Name != ".":
+			imported[path] = spec.Name.Name
+		}
+	}
+
+	valid := true
+	res := types.TypeString(typ, func(pkg *types.Package) string {
+		if pkg == pass.Pkg {
+			return ""
+		}
+
+		name, ok := imported[pkg.Path()]
+		if !ok {
+			valid = false
+		}
+
+		return name
+	})
+
+	return res, valid
+}
@@ -0,0 +1,20 @@
+package analyzer_test
+
+import (
+	"testing"
+
+	"github.com/kazhuravlev/optional/analyzer"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestAnalyzer(t *testing.T) {
+	t.Parallel()
+
+	analysistest.Run(t, analysistest.TestData(), analyzer.Analyzer, "a")
+}
+
+func TestAnalyzer_SuggestedFixes(t *testing.T) {
+	t.Parallel()
+
+	analysistest.RunWithSuggestedFixes(t, analysistest.TestData(), analyzer.Analyzer, "fix")
+}
@@ -0,0 +1,46 @@
+// Package golangci registers the optional analyzer as a golangci-lint module
+// plugin. Add it to .custom-gcl.yml:
+//
+//	version: v2.5.0
+//	plugins:
+//	  - module: github.com/kazhuravlev/optional/analyzer
+//	    import: github.com/kazhuravlev/optional/analyzer/golangci
+//	    version: latest
+//
+// and enable it in .golangci.yml:
+//
+//	linters:
+//	  enable:
+//	    - optional
+//	  settings:
+//	    custom:
+//	      optional:
+//	        type: module
+package golangci
+
+import (
+	"github.com/golangci/plugin-module-register/register"
+	"github.com/kazhuravlev/optional/analyzer"
+	"golang.org/x/tools/go/analysis"
+)
+
+func init() { //nolint:gochecknoinits
+	register.Plugin("optional", New)
+}
+
+type plugin struct{}
+
+// New returns the plugin. It has no settings.
+func New(any) (register.LinterPlugin, error) {
+	return plugin{}, nil
+}
+
+// BuildAnalyzers implements register.LinterPlugin.
+func (plugin) BuildAnalyzers() ([]*analysis.Analyzer, error) {
+	return []*analysis.Analyzer{analyzer.Analyzer}, nil
+}
+
+// GetLoadMode implements register.LinterPlugin.
+func (plugin) GetLoadMode() string {
+	return register.LoadModeTypesInfo
+}
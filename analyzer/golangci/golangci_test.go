@@ -0,0 +1,27 @@
+package golangci_test
+
+import (
+	"testing"
+
+	"github.com/golangci/plugin-module-register/register"
+	"github.com/kazhuravlev/optional/analyzer"
+	_ "github.com/kazhuravlev/optional/analyzer/golangci"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/analysis"
+)
+
+func TestPlugin(t *testing.T) {
+	t.Parallel()
+
+	newPlugin, err := register.GetPlugin("optional")
+	require.NoError(t, err)
+
+	plugin, err := newPlugin(nil)
+	require.NoError(t, err)
+	assert.Equal(t, register.LoadModeTypesInfo, plugin.GetLoadMode())
+
+	analyzers, err := plugin.BuildAnalyzers()
+	require.NoError(t, err)
+	assert.Equal(t, []*analysis.Analyzer{analyzer.Analyzer}, analyzers)
+}
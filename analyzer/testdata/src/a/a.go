@@ -0,0 +1,229 @@
+package a
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kazhuravlev/optional"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// checker looks like require, but is not an assertion.
+type checker struct{}
+
+func (checker) True(any, bool) {}
+
+type user struct {
+	Name optional.Val[string]
+	Born optional.Val[time.Time]
+}
+
+func use(...any) {}
+
+func unchecked(v optional.Val[int], u user, p *optional.Val[error], q optional.Quoted[int]) {
+	use(v.Val())      // want `Val\(\) is called without presence check`
+	use(u.Name.Val()) // want `Val\(\) is called without presence check`
+	use(u.Born.Val()) // want `Val\(\) is called without presence check`
+	use(p.Val())      // want `Val\(\) is called without presence check`
+	use(q.Val())      // want `Val\(\) is called without presence check`
+
+	if u.Name.HasVal() {
+		use(v.Val()) // want `Val\(\) is called without presence check`
+	}
+
+	if !v.HasVal() {
+		use(v.Val()) // want `Val\(\) is called without presence check`
+	}
+
+	if !v.HasVal() {
+		use(1)
+	}
+
+	use(v.Val()) // want `Val\(\) is called without presence check`
+
+	if v.HasVal() || u.Name.HasVal() {
+		use(v.Val()) // want `Val\(\) is called without presence check`
+	}
+
+	switch {
+	case v.Val() > 0: // want `Val\(\) is called without presence check`
+	case v.HasVal():
+	}
+
+	use(v.HasVal() || v.Val() > 0) // want `Val\(\) is called without presence check`
+
+	use((v.Val)())      // want `Val\(\) is called without presence check`
+	use((v).Val())      // want `Val\(\) is called without presence check`
+	use((u.Name.Val)()) // want `Val\(\) is called without presence check`
+}
+
+func guarded(v optional.Val[int], u user, p *optional.Val[error], items []optional.Val[int]) {
+	if v.HasVal() {
+		use(v.Val())
+	}
+
+	if u.Name.HasVal() && v.HasVal() {
+		use(u.Name.Val(), v.Val())
+	}
+
+	if !u.Name.HasVal() {
+		use(1)
+	} else {
+		use(u.Name.Val())
+	}
+
+	if p.HasVal() {
+		use(p.Val(), (*p).Val(), (p.Val)())
+	}
+
+	use(v.HasVal() && v.Val() > 0)
+	use(!v.HasVal() || v.Val() > 0)
+
+	switch {
+	case v.HasVal():
+		use(v.Val())
+	}
+
+	for _, item := range items {
+		if !item.HasVal() {
+			continue
+		}
+
+		use(item.Val())
+	}
+
+	func() {
+		if !v.HasVal() {
+			panic(errors.New("empty"))
+		}
+
+		use(v.Val())
+	}()
+}
+
+func earlyReturn(v optional.Val[int]) int {
+	if !v.HasVal() || v.Val() < 0 {
+		return 0
+	}
+
+	return v.Val()
+}
+
+func assigned(v optional.Val[int], w optional.Val[int]) {
+	if !v.HasVal() {
+		v = optional.New(1)
+	}
+
+	if !w.HasVal() {
+		w.Set(1)
+	}
+
+	use(v.Val(), w.Val())
+}
+
+func asserted(t *testing.T, v, w, x optional.Val[int]) {
+	require.True(t, v.HasVal())
+	use(v.Val())
+
+	require.New(t).True(w.HasVal())
+	use(w.Val())
+
+	if assert.True(t, x.HasVal()) {
+		use(x.Val())
+	}
+}
+
+func notAsserted(t *testing.T, v, w optional.Val[int]) {
+	var require checker
+
+	require.True(nil, v.HasVal())
+	use(v.Val()) // want `Val\(\) is called without presence check`
+
+	assert.True(t, w.HasVal())
+	use(w.Val()) // want `Val\(\) is called without presence check`
+}
+
+func reassigned(v, w optional.Val[int], u user, items []optional.Val[int]) {
+	if v.HasVal() {
+		v = optional.Empty[int]()
+		use(v.Val()) // want `Val\(\) is called without presence check`
+	}
+
+	if !w.HasVal() {
+		return
+	}
+
+	w.Reset()
+	use(w.Val()) // want `Val\(\) is called without presence check`
+
+	if !u.Name.HasVal() {
+		return
+	}
+
+	u = user{}
+	use(u.Name.Val()) // want `Val\(\) is called without presence check`
+
+	if !v.HasVal() {
+		return
+	}
+
+	if len(items) > 0 {
+		v = items[0]
+	}
+
+	use(v.Val()) // want `Val\(\) is called without presence check`
+
+	for _, item := range items {
+		if !item.HasVal() {
+			continue
+		}
+
+		item = optional.Empty[int]()
+		use(item.Val()) // want `Val\(\) is called without presence check`
+	}
+
+	if !v.HasVal() {
+		return
+	}
+
+	for range items {
+		use(v.Val()) // want `Val\(\) is called without presence check`
+		v = optional.Empty[int]()
+	}
+}
+
+func reassignedBeforeCheck(v optional.Val[int]) {
+	v = optional.Empty[int]()
+
+	if !v.HasVal() {
+		return
+	}
+
+	use(v.Val())
+}
+
+func get(v optional.Val[int]) {
+	val, _ := v.Get() // want `ok result of Get\(\) is discarded`
+	use(val)
+
+	var other, _ = v.Get() // want `ok result of Get\(\) is discarded`
+	use(other)
+
+	val, _ = v.Get() // want `ok result of Get\(\) is discarded`
+
+	_, _ = v.Get() // want `ok result of Get\(\) is discarded`
+
+	if val, ok := v.Get(); ok {
+		use(val)
+	}
+}
+
+func compare(a, b optional.Val[int], p *optional.Val[int]) {
+	use(a == b)               // want `optional.Val is compared with ==`
+	use(a != optional.New(1)) // want `optional.Val is compared with !=`
+	use(p == nil)
+	use(*p == a)      // want `optional.Val is compared with ==`
+	use(a.Val() == 1) // want `Val\(\) is called without presence check`
+}
@@ -0,0 +1,40 @@
+package fix
+
+import (
+	stdtime "time"
+
+	"github.com/kazhuravlev/optional"
+)
+
+type point struct{ X, Y int }
+
+type status string
+
+func use(...any) {}
+
+func fix[T any](
+	i optional.Val[int],
+	s optional.Val[status],
+	b optional.Val[bool],
+	ts optional.Val[stdtime.Time],
+	pt optional.Val[point],
+	list optional.Val[[]string],
+	gen optional.Val[T],
+	anon optional.Val[struct{}],
+) {
+	use(i.Val())    // want `Val\(\) is called without presence check`
+	use(s.Val())    // want `Val\(\) is called without presence check`
+	use(b.Val())    // want `Val\(\) is called without presence check`
+	use(ts.Val())   // want `Val\(\) is called without presence check`
+	use(pt.Val())   // want `Val\(\) is called without presence check`
+	use(list.Val()) // want `Val\(\) is called without presence check`
+	use(gen.Val())  // want `Val\(\) is called without presence check`
+	use(anon.Val()) // want `Val\(\) is called without presence check`
+	use((i.Val)())  // want `Val\(\) is called without presence check`
+
+	val, _ := i.Get() // want `ok result of Get\(\) is discarded`
+	use(val)
+
+	var str, _ = s.Get() // want `ok result of Get\(\) is discarded`
+	use(str)
+}
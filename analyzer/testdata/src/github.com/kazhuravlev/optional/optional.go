@@ -0,0 +1,25 @@
+// Package optional is a stub of github.com/kazhuravlev/optional for tests.
+package optional
+
+type Val[T any] struct {
+	value  T
+	hasVal bool
+}
+
+func New[T any](val T) Val[T] { return Val[T]{value: val, hasVal: true} }
+func Empty[T any]() Val[T]    { return Val[T]{} }
+
+func (v Val[T]) Get() (T, bool)     { return v.value, v.hasVal }
+func (v Val[T]) Val() T             { return v.value }
+func (v Val[T]) HasVal() bool       { return v.hasVal }
+func (v Val[T]) ValDefault(def T) T { return def }
+func (v *Val[T]) Set(val T)         { v.value, v.hasVal = val, true }
+func (v *Val[T]) Reset()            { *v = Val[T]{} }
+
+type Quoted[T any] struct {
+	val Val[T]
+}
+
+func (v Quoted[T]) Get() (T, bool) { return v.val.Get() }
+func (v Quoted[T]) Val() T         { return v.val.Val() }
+func (v Quoted[T]) HasVal() bool   { return v.val.HasVal() }
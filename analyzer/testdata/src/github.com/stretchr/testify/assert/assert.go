@@ -0,0 +1,8 @@
+// Package assert is a stub of github.com/stretchr/testify/assert for tests.
+package assert
+
+type TestingT interface {
+	Errorf(format string, args ...any)
+}
+
+func True(t TestingT, value bool, msgAndArgs ...any) bool { return value }
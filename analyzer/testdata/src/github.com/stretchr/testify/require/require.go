@@ -0,0 +1,17 @@
+// Package require is a stub of github.com/stretchr/testify/require for tests.
+package require
+
+type TestingT interface {
+	Errorf(format string, args ...any)
+	FailNow()
+}
+
+func True(t TestingT, value bool, msgAndArgs ...any)        {}
+func Truef(t TestingT, value bool, msg string, args ...any) {}
+func False(t TestingT, value bool, msgAndArgs ...any)       {}
+
+type Assertions struct{ t TestingT }
+
+func New(t TestingT) *Assertions { return &Assertions{t: t} }
+
+func (a *Assertions) True(value bool, msgAndArgs ...any) {}
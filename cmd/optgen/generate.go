@@ -0,0 +1,541 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/tools/go/packages"
+)
+
+const (
+	optionalPath = "github.com/kazhuravlev/optional"
+	marker       = "//optional:gen"
+	header       = "// Code generated by optgen. DO NOT EDIT.\n"
+	// maxFields is the number of bits in the presence bitmap.
+	maxFields = 64
+)
+
+var (
+	errNotStruct      = errors.New("annotated type is not a struct")
+	errTooManyFields  = errors.New("too many optional fields")
+	errNameConflict   = errors.New("conflicting accessor names")
+	errPackageInvalid = errors.New("package has errors")
+)
+
+// Config of the generator.
+type Config struct {
+	// Dir is the directory to run the build system in.
+	Dir string
+	// Output is the file name of generated code in the directory of each package.
+	Output string
+	// Tags are build tags.
+	Tags []string
+}
+
+// field is an optional field of an annotated struct.
+type field struct {
+	// name is the suffix of generated accessors.
+	name string
+	// path is the selector of the field relative to the struct.
+	path string
+	// elem is the type of inner value.
+	elem types.Type
+}
+
+// target is an annotated struct.
+type target struct {
+	named  *types.Named
+	fields []field
+}
+
+// Generate writes generated code for all packages matched by patterns.
+func Generate(cfg Config, patterns ...string) error {
+	pkgs, err := load(cfg, patterns)
+	if err != nil {
+		return err
+	}
+
+	for _, pkg := range pkgs {
+		if len(pkg.GoFiles) == 0 {
+			continue
+		}
+
+		res, err := generatePackage(pkg)
+		if err != nil {
+			return fmt.Errorf("generate %s: %w", pkg.PkgPath, err)
+		}
+
+		filename := filepath.Join(filepath.Dir(pkg.GoFiles[0]), cfg.Output)
+		if res == nil {
+			if err := removeGenerated(filename); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		if err := os.WriteFile(filename, res, 0o644); err != nil { //nolint:gosec,mnd
+			return fmt.Errorf("write %s: %w", filename, err)
+		}
+	}
+
+	return nil
+}
+
+// load loads packages with types. Previously generated files are replaced
+// with empty files, so that packages can be loaded after removal of fields.
+func load(cfg Config, patterns []string) ([]*packages.Package, error) {
+	var buildFlags []string
+	if len(cfg.Tags) != 0 {
+		buildFlags = append(buildFlags, "-tags="+strings.Join(cfg.Tags, ","))
+	}
+
+	pkgs, err := packages.Load(&packages.Config{ //nolint:exhaustruct
+		Mode:       packages.NeedName | packages.NeedFiles,
+		Dir:        cfg.Dir,
+		BuildFlags: buildFlags,
+	}, patterns...)
+	if err != nil {
+		return nil, fmt.Errorf("list packages: %w", err)
+	}
+
+	overlay := make(map[string][]byte)
+	for _, pkg := range pkgs {
+		for _, filename := range pkg.GoFiles {
+			if filepath.Base(filename) == cfg.Output && isGenerated(filename) {
+				overlay[filename] = []byte(header + "\npackage " + pkg.Name + "\n")
+			}
+		}
+	}
+
+	// Dependencies are type checked from source, because overlays invalidate
+	// export data and export data of newer toolchains may be unsupported.
+	pkgs, err = packages.Load(&packages.Config{ //nolint:exhaustruct
+		Mode: packages.NeedName | packages.NeedFiles | packages.NeedSyntax | packages.NeedTypes |
+			packages.NeedTypesInfo | packages.NeedImports | packages.NeedDeps,
+		Dir:        cfg.Dir,
+		BuildFlags: buildFlags,
+		Overlay:    overlay,
+	}, patterns...)
+	if err != nil {
+		return nil, fmt.Errorf("load packages: %w", err)
+	}
+
+	for _, pkg := range pkgs {
+		if len(pkg.Errors) != 0 {
+			return nil, fmt.Errorf("%w: %s: %s", errPackageInvalid, pkg.PkgPath, pkg.Errors[0].Error())
+		}
+	}
+
+	return pkgs, nil
+}
+
+// isGenerated reports whether the file was written by optgen.
+func isGenerated(filename string) bool {
+	src, err := os.ReadFile(filename)
+	if err != nil {
+		return false
+	}
+
+	return bytes.HasPrefix(src, []byte(header))
+}
+
+// removeGenerated removes a stale generated file.
+func removeGenerated(filename string) error {
+	if !isGenerated(filename) {
+		return nil
+	}
+
+	if err := os.Remove(filename); err != nil {
+		return fmt.Errorf("remove %s: %w", filename, err)
+	}
+
+	return nil
+}
+
+// generatePackage returns formatted source code for annotated structs of the
+// package or nil when there are no such structs.
+func generatePackage(pkg *packages.Package) ([]byte, error) {
+	targets, err := findTargets(pkg)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(targets) == 0 {
+		return nil, nil
+	}
+
+	imps := newImports(pkg.Types)
+	imps.qualifier(types.NewPackage(optionalPath, "optional"))
+
+	var body bytes.Buffer
+	for _, tgt := range targets {
+		writeTarget(&body, imps, tgt)
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(header)
+	fmt.Fprintf(&buf, "\npackage %s\n\n", pkg.Name)
+	imps.write(&buf)
+	buf.Write(body.Bytes())
+
+	res, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("format source: %w", err)
+	}
+
+	return res, nil
+}
+
+// findTargets returns annotated structs in order of declaration.
+func findTargets(pkg *packages.Package) ([]target, error) {
+	files := slices.Clone(pkg.Syntax)
+	slices.SortFunc(files, func(a, b *ast.File) int {
+		return strings.Compare(pkg.Fset.File(a.Pos()).Name(), pkg.Fset.File(b.Pos()).Name())
+	})
+
+	var targets []target
+
+	for _, file := range files {
+		for _, decl := range file.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.TYPE {
+				continue
+			}
+
+			for _, spec := range gen.Specs {
+				typeSpec := spec.(*ast.TypeSpec) //nolint:forcetypeassert
+				if !hasMarker(typeSpec.Doc) && (len(gen.Specs) != 1 || !hasMarker(gen.Doc)) {
+					continue
+				}
+
+				tgt, err := newTarget(pkg, typeSpec)
+				if err != nil {
+					return nil, fmt.Errorf("%s: %w", pkg.Fset.Position(typeSpec.Pos()), err)
+				}
+
+				if len(tgt.fields) != 0 {
+					targets = append(targets, tgt)
+				}
+			}
+		}
+	}
+
+	return targets, nil
+}
+
+func hasMarker(doc *ast.CommentGroup) bool {
+	if doc == nil {
+		return false
+	}
+
+	for _, comment := range doc.List {
+		if strings.TrimSpace(comment.Text) == marker {
+			return true
+		}
+	}
+
+	return false
+}
+
+func newTarget(pkg *packages.Package, spec *ast.TypeSpec) (target, error) {
+	obj := pkg.TypesInfo.Defs[spec.Name]
+
+	named, ok := obj.Type().(*types.Named)
+	if !ok {
+		return target{}, fmt.Errorf("%w: %s", errNotStruct, spec.Name.Name)
+	}
+
+	st, ok := named.Underlying().(*types.Struct)
+	if !ok {
+		return target{}, fmt.Errorf("%w: %s", errNotStruct, spec.Name.Name)
+	}
+
+	fields, err := collectFields(pkg.Types, st)
+	if err != nil {
+		return target{}, fmt.Errorf("%s: %w", spec.Name.Name, err)
+	}
+
+	return target{named: named, fields: fields}, nil
+}
+
+// collectFields returns optional fields of st including fields promoted from
+// embedded structs. Fields are visited in breadth-first order, so that
+// shallower fields hide deeper fields with the same name like in Go.
+func collectFields(pkg *types.Package, st *types.Struct) ([]field, error) {
+	type level struct {
+		st     *types.Struct
+		prefix string
+	}
+
+	var (
+		fields  []field
+		seen    = make(map[string]bool)
+		current = []level{{st: st, prefix: ""}}
+	)
+
+	for len(current) != 0 {
+		var (
+			next   []level
+			counts = make(map[string]int)
+			found  []field
+		)
+
+		for _, lvl := range current {
+			for i := range lvl.st.NumFields() {
+				fld := lvl.st.Field(i)
+				if seen[fld.Name()] {
+					continue
+				}
+
+				counts[fld.Name()]++
+
+				if !fld.Exported() && fld.Pkg() != pkg {
+					continue
+				}
+
+				path := lvl.prefix + fld.Name()
+				if fld.Embedded() {
+					if sub, ok := types.Unalias(fld.Type()).Underlying().(*types.Struct); ok {
+						next = append(next, level{st: sub, prefix: path + "."})
+					}
+
+					continue
+				}
+
+				if elem, ok := valElem(fld.Type()); ok {
+					found = append(found, field{name: exportName(fld.Name()), path: path, elem: elem})
+				}
+			}
+		}
+
+		for _, fld := range found {
+			// Ambiguous selectors at the same depth are not accessible.
+			if counts[fld.path[strings.LastIndexByte(fld.path, '.')+1:]] == 1 {
+				fields = append(fields, fld)
+			}
+		}
+
+		for name := range counts {
+			seen[name] = true
+		}
+
+		current = next
+	}
+
+	if len(fields) > maxFields {
+		return nil, fmt.Errorf("%w: %d > %d", errTooManyFields, len(fields), maxFields)
+	}
+
+	names := make(map[string]string, len(fields))
+	for _, fld := range fields {
+		if prev, ok := names[fld.name]; ok {
+			return nil, fmt.Errorf("%w: %s and %s", errNameConflict, prev, fld.path)
+		}
+
+		names[fld.name] = fld.path
+	}
+
+	return fields, nil
+}
+
+// valElem returns T of optional.Val[T].
+func valElem(typ types.Type) (types.Type, bool) {
+	named, ok := types.Unalias(typ).(*types.Named)
+	if !ok {
+		return nil, false
+	}
+
+	obj := named.Obj()
+	if obj.Pkg() == nil || obj.Pkg().Path() != optionalPath || obj.Name() != "Val" {
+		return nil, false
+	}
+
+	return named.TypeArgs().At(0), true
+}
+
+func exportName(name string) string {
+	r, size := utf8.DecodeRuneInString(name)
+
+	return string(unicode.ToUpper(r)) + name[size:]
+}
+
+func writeTarget(buf *bytes.Buffer, imps *imports, tgt target) {
+	name := tgt.named.Obj().Name()
+	bitmap := name + "Fields"
+	recv := receiverName(tgt.named)
+	recvType := name + typeParams(tgt.named)
+	optName := imps.qualifier(types.NewPackage(optionalPath, "optional"))
+
+	fmt.Fprintf(buf, "// %s is a bitmap of present optional fields of %s.\n", bitmap, name)
+	fmt.Fprintf(buf, "type %s uint64\n\n", bitmap)
+	fmt.Fprintf(buf, "// Optional fields of %s.\n", name)
+	buf.WriteString("const (\n")
+
+	for i, fld := range tgt.fields {
+		if i == 0 {
+			fmt.Fprintf(buf, "%sField%s %s = 1 << iota\n", name, fld.name, bitmap)
+		} else {
+			fmt.Fprintf(buf, "%sField%s\n", name, fld.name)
+		}
+	}
+
+	buf.WriteString(")\n\n")
+
+	fmt.Fprintf(buf, "// Has reports whether all given fields are present.\n")
+	fmt.Fprintf(buf, "func (f %s) Has(fields %s) bool {\nreturn f&fields == fields\n}\n\n", bitmap, bitmap)
+
+	fmt.Fprintf(buf, "// Fields returns a bitmap of present optional fields.\n")
+	fmt.Fprintf(buf, "func (%s %s) Fields() %s {\nvar res %s\n", recv, recvType, bitmap, bitmap)
+
+	for _, fld := range tgt.fields {
+		fmt.Fprintf(buf, "if %s.%s.HasVal() {\nres |= %sField%s\n}\n", recv, fld.path, name, fld.name)
+	}
+
+	buf.WriteString("\nreturn res\n}\n\n")
+
+	for _, fld := range tgt.fields {
+		elem := types.TypeString(fld.elem, imps.qualifier)
+
+		fmt.Fprintf(buf, "// With%s returns a copy of %s with %s set to val.\n", fld.name, name, fld.path)
+		fmt.Fprintf(buf, "func (%s %s) With%s(val %s) %s {\n%s.%s = %s.New(val)\n\nreturn %s\n}\n\n",
+			recv, recvType, fld.name, elem, recvType, recv, fld.path, optName, recv)
+
+		fmt.Fprintf(buf, "// Has%s reports whether %s has a value.\n", fld.name, fld.path)
+		fmt.Fprintf(buf, "func (%s %s) Has%s() bool {\nreturn %s.%s.HasVal()\n}\n\n",
+			recv, recvType, fld.name, recv, fld.path)
+
+		fmt.Fprintf(buf, "// Get%s returns the value of %s and whether it is present.\n", fld.name, fld.path)
+		fmt.Fprintf(buf, "func (%s %s) Get%s() (%s, bool) {\nreturn %s.%s.Get()\n}\n\n",
+			recv, recvType, fld.name, elem, recv, fld.path)
+
+		fmt.Fprintf(buf, "// Clear%s resets %s to an empty value.\n", fld.name, fld.path)
+		fmt.Fprintf(buf, "func (%s *%s) Clear%s() {\n%s.%s.Reset()\n}\n\n",
+			recv, recvType, fld.name, recv, fld.path)
+	}
+}
+
+// receiverName returns the lowercase first letter of the type name unless it
+// is used by a type parameter.
+func receiverName(named *types.Named) string {
+	r, _ := utf8.DecodeRuneInString(named.Obj().Name())
+	recv := string(unicode.ToLower(r))
+
+	for i := range named.TypeParams().Len() {
+		if named.TypeParams().At(i).Obj().Name() == recv {
+			return "recv"
+		}
+	}
+
+	return recv
+}
+
+// typeParams returns type parameters of the receiver like [K, V].
+func typeParams(named *types.Named) string {
+	params := named.TypeParams()
+	if params.Len() == 0 {
+		return ""
+	}
+
+	names := make([]string, params.Len())
+	for i := range params.Len() {
+		names[i] = params.At(i).Obj().Name()
+	}
+
+	return "[" + strings.Join(names, ", ") + "]"
+}
+
+// imports assigns unique names to imported packages in order of use.
+type imports struct {
+	self  *types.Package
+	names map[string]string
+	used  map[string]bool
+	paths []string
+}
+
+func newImports(self *types.Package) *imports {
+	return &imports{
+		self:  self,
+		names: make(map[string]string),
+		used:  make(map[string]bool),
+		paths: nil,
+	}
+}
+
+// qualifier implements types.Qualifier.
+func (i *imports) qualifier(pkg *types.Package) string {
+	if pkg.Path() == i.self.Path() {
+		return ""
+	}
+
+	if name, ok := i.names[pkg.Path()]; ok {
+		return name
+	}
+
+	name := pkg.Name()
+	for n := 2; i.used[name] || i.self.Scope().Lookup(name) != nil; n++ {
+		name = fmt.Sprintf("%s%d", pkg.Name(), n)
+	}
+
+	i.names[pkg.Path()] = name
+	i.used[name] = true
+	i.paths = append(i.paths, pkg.Path())
+
+	return name
+}
+
+func (i *imports) write(buf *bytes.Buffer) {
+	paths := slices.Clone(i.paths)
+	// Standard library goes first like in goimports.
+	slices.SortFunc(paths, func(a, b string) int {
+		if isStd(a) != isStd(b) {
+			if isStd(a) {
+				return -1
+			}
+
+			return 1
+		}
+
+		return strings.Compare(a, b)
+	})
+
+	buf.WriteString("import (\n")
+
+	for idx, path := range paths {
+		if idx != 0 && isStd(paths[idx-1]) && !isStd(path) {
+			buf.WriteString("\n")
+		}
+
+		if name := i.names[path]; name != packageName(path) {
+			fmt.Fprintf(buf, "%s %q\n", name, path)
+		} else {
+			fmt.Fprintf(buf, "%q\n", path)
+		}
+	}
+
+	buf.WriteString(")\n\n")
+}
+
+// isStd reports whether the package is from the standard library.
+func isStd(path string) bool {
+	first, _, _ := strings.Cut(path, "/")
+
+	return !strings.Contains(first, ".")
+}
+
+// packageName returns the default name of the package, which is the last
+// element of the import path.
+func packageName(path string) string {
+	return path[strings.LastIndexByte(path, '/')+1:]
+}
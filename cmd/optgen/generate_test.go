@@ -0,0 +1,103 @@
+package main
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/packages"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+const output = "optgen_gen.go"
+
+func loadTestdata(t *testing.T, dir string) *packages.Package {
+	t.Helper()
+
+	pkgs, err := load(Config{Dir: filepath.Join("testdata", dir), Output: output, Tags: nil}, []string{"."})
+	require.NoError(t, err)
+	require.Len(t, pkgs, 1)
+
+	return pkgs[0]
+}
+
+func TestGeneratePackage(t *testing.T) {
+	t.Parallel()
+
+	table := []string{
+		"basic",
+		"generic",
+		"embedded",
+		"crosspkg/model",
+	}
+
+	for _, dir := range table {
+		t.Run(dir, func(t *testing.T) {
+			t.Parallel()
+
+			pkg := loadTestdata(t, dir)
+
+			res, err := generatePackage(pkg)
+			require.NoError(t, err)
+
+			golden := filepath.Join("testdata", dir, output+".golden")
+			if *update {
+				require.NoError(t, os.WriteFile(golden, res, 0o644)) //nolint:gosec
+			}
+
+			expected, err := os.ReadFile(golden)
+			require.NoError(t, err)
+			assert.Equal(t, string(expected), string(res))
+
+			// Output is deterministic.
+			again, err := generatePackage(pkg)
+			require.NoError(t, err)
+			assert.Equal(t, string(res), string(again))
+
+			// Generated code compiles together with the package.
+			filename, err := filepath.Abs(filepath.Join("testdata", dir, output))
+			require.NoError(t, err)
+
+			pkgs, err := packages.Load(&packages.Config{ //nolint:exhaustruct
+				Mode:    packages.NeedName | packages.NeedTypes | packages.NeedImports | packages.NeedDeps,
+				Dir:     filepath.Join("testdata", dir),
+				Overlay: map[string][]byte{filename: res},
+			}, ".")
+			require.NoError(t, err)
+			require.Len(t, pkgs, 1)
+			assert.Empty(t, pkgs[0].Errors)
+		})
+	}
+}
+
+func TestGeneratePackage_Errors(t *testing.T) {
+	t.Parallel()
+
+	table := []struct {
+		dir string
+		err error
+	}{
+		{dir: "notstruct", err: errNotStruct},
+		{dir: "conflict", err: errNameConflict},
+	}
+
+	for _, row := range table {
+		t.Run(row.dir, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := generatePackage(loadTestdata(t, row.dir))
+			require.ErrorIs(t, err, row.err)
+		})
+	}
+}
+
+func TestLoad_Invalid(t *testing.T) {
+	t.Parallel()
+
+	_, err := load(Config{Dir: "testdata", Output: output, Tags: nil}, []string{"./missing"})
+	require.Error(t, err)
+}
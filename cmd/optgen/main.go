@@ -0,0 +1,60 @@
+// Command optgen generates accessors and builders for structs with
+// optional.Val fields. Annotate a struct with //optional:gen:
+//
+//	//go:generate go run github.com/kazhuravlev/optional/cmd/optgen
+//
+//	//optional:gen
+//	type User struct {
+//		Nickname optional.Val[string]
+//	}
+//
+// For each optional field X of type optional.Val[T] it generates:
+//
+//	func (u User) WithX(val T) User
+//	func (u User) HasX() bool
+//	func (u User) GetX() (T, bool)
+//	func (u *User) ClearX()
+//
+// and a bitmap of present fields:
+//
+//	func (u User) Fields() UserFields
+//
+// Fields of embedded structs are included. Generated code is written into
+// optgen_gen.go in the directory of each package.
+//
+// Usage:
+//
+//	optgen [-output optgen_gen.go] [-tags a,b] [packages]
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+)
+
+func main() {
+	output := flag.String("output", "optgen_gen.go", "file name of generated code")
+	tags := flag.String("tags", "", "comma-separated list of build tags")
+	flag.Parse()
+
+	cfg := Config{
+		Dir:    "",
+		Output: *output,
+		Tags:   nil,
+	}
+	if *tags != "" {
+		cfg.Tags = strings.Split(*tags, ",")
+	}
+
+	patterns := flag.Args()
+	if len(patterns) == 0 {
+		patterns = []string{"."}
+	}
+
+	if err := Generate(cfg, patterns...); err != nil {
+		fmt.Fprintln(os.Stderr, "optgen:", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,37 @@
+package basic
+
+import (
+	"time"
+
+	"github.com/kazhuravlev/optional"
+)
+
+//optional:gen
+type User struct {
+	ID        int64
+	Name      string
+	nickname  optional.Val[string]
+	Age       optional.Val[int]
+	Avatar    optional.Val[[]byte]
+	DeletedAt optional.Val[time.Time]
+	Parent    optional.Val[*User]
+}
+
+type (
+	//optional:gen
+	Settings struct {
+		Theme optional.Val[string]
+	}
+
+	// NotAnnotated has no generated methods.
+	NotAnnotated struct {
+		Theme optional.Val[string]
+	}
+)
+
+// Empty has no optional fields, so no code is generated.
+//
+//optional:gen
+type Empty struct {
+	Name string
+}
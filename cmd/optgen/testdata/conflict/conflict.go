@@ -0,0 +1,9 @@
+package conflict
+
+import "github.com/kazhuravlev/optional"
+
+//optional:gen
+type User struct {
+	Name optional.Val[string]
+	name optional.Val[string]
+}
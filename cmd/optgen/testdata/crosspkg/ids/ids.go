@@ -0,0 +1,10 @@
+package ids
+
+import "github.com/kazhuravlev/optional"
+
+type ID string
+
+type Audit struct {
+	UpdatedBy optional.Val[ID]
+	reason    optional.Val[string]
+}
@@ -0,0 +1,21 @@
+package model
+
+import (
+	"time"
+
+	"github.com/kazhuravlev/optional"
+	idspkg "github.com/kazhuravlev/optional/cmd/optgen/testdata/crosspkg/ids"
+)
+
+// ids is a package-level identifier, so the generated code imports package
+// ids under another name.
+var ids = []idspkg.ID{}
+
+//optional:gen
+type Order struct {
+	idspkg.Audit
+
+	ID       idspkg.ID
+	Owner    optional.Val[idspkg.ID]
+	Deadline optional.Val[time.Duration]
+}
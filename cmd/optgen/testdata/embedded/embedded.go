@@ -0,0 +1,32 @@
+package embedded
+
+import "github.com/kazhuravlev/optional"
+
+type Base struct {
+	ID        optional.Val[int64]
+	CreatedBy optional.Val[string]
+}
+
+type Meta struct {
+	Version optional.Val[int]
+}
+
+type Left struct {
+	Note optional.Val[string]
+}
+
+type Right struct {
+	Note optional.Val[string]
+}
+
+//optional:gen
+type Document struct {
+	Base
+	*Meta
+	Left
+	Right
+
+	// CreatedBy hides Base.CreatedBy.
+	CreatedBy optional.Val[int]
+	Title     optional.Val[string]
+}
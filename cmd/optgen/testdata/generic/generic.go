@@ -0,0 +1,16 @@
+package generic
+
+import "github.com/kazhuravlev/optional"
+
+//optional:gen
+type Pair[K comparable, V any] struct {
+	Key   optional.Val[K]
+	Value optional.Val[V]
+	Items optional.Val[map[K][]V]
+}
+
+//optional:gen
+type Page[p any] struct {
+	Cursor optional.Val[string]
+	Items  []p
+}
@@ -0,0 +1,4 @@
+package notstruct
+
+//optional:gen
+type Status int
@@ -0,0 +1,90 @@
+// Command optional-migrate rewrites struct fields of types *T, sql.NullXxx,
+// sql.Null[T] and guregu/null types to optional.Val[T] and updates uses of
+// these fields:
+//
+//	if u.Nickname != nil { use(*u.Nickname) } // if nickname, ok := u.Nickname.Get(); ok { use(nickname) }
+//	u.Nickname = &name                         // u.Nickname = optional.New(name)
+//	u.Nickname = nil                           // u.Nickname.Reset()
+//	u.Email.Valid                              // u.Email.HasVal()
+//	u.Email.String                             // u.Email.Val()
+//	null.StringFrom(s)                         // optional.New(s)
+//
+// Uses which can not be rewritten automatically are reported as warnings.
+//
+// Usage:
+//
+//	optional-migrate -fields User.Nickname,User.Email,Order.* [-w] [-tags a,b] [packages]
+//
+// Without -w the tool only prints names of files which would be rewritten.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+)
+
+func main() {
+	fields := flag.String("fields", "", "comma-separated fields to migrate: Type.Field, Type.* or import/path.Type.Field")
+	write := flag.Bool("w", false, "write result to source files")
+	tags := flag.String("tags", "", "comma-separated list of build tags")
+	flag.Parse()
+
+	cfg := Config{
+		Dir:    "",
+		Fields: nil,
+		Tags:   nil,
+	}
+
+	if *fields != "" {
+		cfg.Fields = strings.Split(*fields, ",")
+	}
+
+	if *tags != "" {
+		cfg.Tags = strings.Split(*tags, ",")
+	}
+
+	patterns := flag.Args()
+	if len(patterns) == 0 {
+		patterns = []string{"."}
+	}
+
+	if err := run(cfg, *write, patterns); err != nil {
+		fmt.Fprintln(os.Stderr, "optional-migrate:", err)
+		os.Exit(1)
+	}
+}
+
+func run(cfg Config, write bool, patterns []string) error {
+	res, err := Migrate(cfg, patterns...)
+	if err != nil {
+		return err
+	}
+
+	for _, warning := range res.Warnings {
+		fmt.Fprintln(os.Stderr, warning)
+	}
+
+	filenames := make([]string, 0, len(res.Files))
+	for filename := range res.Files {
+		filenames = append(filenames, filename)
+	}
+
+	slices.Sort(filenames)
+
+	for _, filename := range filenames {
+		fmt.Println(filename) //nolint:forbidigo
+
+		if !write {
+			continue
+		}
+
+		if err := os.WriteFile(filename, res.Files[filename], 0o644); err != nil { //nolint:gosec,mnd
+			return fmt.Errorf("write %s: %w", filename, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,530 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"slices"
+	"strings"
+
+	"golang.org/x/tools/go/ast/astutil"
+	"golang.org/x/tools/go/packages"
+	"golang.org/x/tools/imports"
+)
+
+const (
+	optionalPath = "github.com/kazhuravlev/optional"
+	sqlPath      = "database/sql"
+)
+
+// guregu/null packages which types can be migrated.
+var guregPaths = []string{
+	"gopkg.in/guregu/null.v3",
+	"gopkg.in/guregu/null.v4",
+	"github.com/guregu/null/v5",
+	"github.com/guregu/null/v6",
+}
+
+// Names of fields with the value of database/sql null types.
+var sqlValueFields = map[string]string{
+	"Null":        "V",
+	"NullString":  "String",
+	"NullInt64":   "Int64",
+	"NullInt32":   "Int32",
+	"NullInt16":   "Int16",
+	"NullByte":    "Byte",
+	"NullFloat64": "Float64",
+	"NullBool":    "Bool",
+	"NullTime":    "Time",
+}
+
+// Names of fields with the value of guregu/null types.
+var guregValueFields = map[string]string{
+	"Value":  "V",
+	"String": "String",
+	"Int":    "Int64",
+	"Int32":  "Int32",
+	"Int16":  "Int16",
+	"Byte":   "Byte",
+	"Float":  "Float64",
+	"Bool":   "Bool",
+	"Time":   "Time",
+}
+
+var (
+	errNoFields       = errors.New("no fields to migrate")
+	errInvalidPattern = errors.New("invalid field pattern")
+	errPackageInvalid = errors.New("package has errors")
+)
+
+// Config of the migration.
+type Config struct {
+	// Dir is the directory to run the build system in.
+	Dir string
+	// Fields are patterns of fields to migrate: Type.Field, Type.* or
+	// import/path.Type.Field.
+	Fields []string
+	// Tags are build tags.
+	Tags []string
+}
+
+// Result of the migration.
+type Result struct {
+	// Files are rewritten files by name.
+	Files map[string][]byte
+	// Warnings are uses of fields which must be migrated manually or whose
+	// behaviour is changed by the rewrite.
+	Warnings []string
+}
+
+type kind int
+
+const (
+	// kindPointer is *T.
+	kindPointer kind = iota + 1
+	// kindSQL is sql.NullXxx or sql.Null[T].
+	kindSQL
+	// kindGureg is a type from guregu/null.
+	kindGureg
+)
+
+// target is a field to migrate.
+type target struct {
+	kind kind
+	// elem is the type of inner value of optional.Val.
+	elem types.Type
+	// name is the name of original type for kindSQL and kindGureg.
+	name string
+	// pkg is the original package for kindGureg.
+	pkg *types.Package
+	// valueField is the name of field with the value for kindSQL and kindGureg.
+	valueField string
+}
+
+// fieldKey identifies a field across type checked variants of the package.
+type fieldKey struct {
+	filename string
+	offset   int
+}
+
+// Migrate rewrites chosen fields and their uses in packages matched by
+// patterns. Files are not written.
+func Migrate(cfg Config, patterns ...string) (Result, error) {
+	if len(cfg.Fields) == 0 {
+		return Result{}, errNoFields
+	}
+
+	pkgs, err := load(cfg, patterns)
+	if err != nil {
+		return Result{}, err
+	}
+
+	res := Result{Files: make(map[string][]byte), Warnings: nil}
+
+	fields, warnings, err := selectFields(pkgs, cfg.Fields)
+	if err != nil {
+		return Result{}, err
+	}
+
+	res.Warnings = append(res.Warnings, warnings...)
+
+	if len(fields) == 0 {
+		return Result{}, errNoFields
+	}
+
+	seen := make(map[string]bool)
+
+	for _, pkg := range pkgs {
+		for i, file := range pkg.Syntax {
+			filename := pkg.CompiledGoFiles[i]
+			if seen[filename] {
+				continue
+			}
+
+			seen[filename] = true
+
+			src, err := os.ReadFile(filename)
+			if err != nil {
+				return Result{}, fmt.Errorf("read %s: %w", filename, err)
+			}
+
+			rw := newRewriter(pkg, file, fields)
+			rw.walk(file)
+
+			if len(rw.edits) != 0 {
+				out, err := rw.apply(src)
+				if err != nil {
+					return Result{}, fmt.Errorf("rewrite %s: %w", filename, err)
+				}
+
+				res.Files[filename] = out
+			}
+
+			res.Warnings = append(res.Warnings, rw.warnings...)
+		}
+	}
+
+	return res, nil
+}
+
+func load(cfg Config, patterns []string) ([]*packages.Package, error) {
+	var buildFlags []string
+	if len(cfg.Tags) != 0 {
+		buildFlags = append(buildFlags, "-tags="+strings.Join(cfg.Tags, ","))
+	}
+
+	// Dependencies are type checked from source, because export data of
+	// newer toolchains may be unsupported.
+	pkgs, err := packages.Load(&packages.Config{ //nolint:exhaustruct
+		Mode: packages.NeedName | packages.NeedFiles | packages.NeedCompiledGoFiles | packages.NeedSyntax |
+			packages.NeedTypes | packages.NeedTypesInfo | packages.NeedImports | packages.NeedDeps,
+		Dir:        cfg.Dir,
+		BuildFlags: buildFlags,
+		Tests:      true,
+	}, patterns...)
+	if err != nil {
+		return nil, fmt.Errorf("load packages: %w", err)
+	}
+
+	for _, pkg := range pkgs {
+		if len(pkg.Errors) != 0 {
+			return nil, fmt.Errorf("%w: %s: %s", errPackageInvalid, pkg.PkgPath, pkg.Errors[0].Error())
+		}
+	}
+
+	return pkgs, nil
+}
+
+// pattern is a parsed field pattern.
+type pattern struct {
+	pkg   string
+	typ   string
+	field string
+}
+
+func parsePattern(str string) (pattern, error) {
+	rest, field, ok := cutLast(str, ".")
+	if !ok || rest == "" || field == "" {
+		return pattern{}, fmt.Errorf("%w: %q", errInvalidPattern, str)
+	}
+
+	pkg, typ, ok := cutLast(rest, ".")
+	if !ok {
+		return pattern{pkg: "", typ: rest, field: field}, nil
+	}
+
+	return pattern{pkg: pkg, typ: typ, field: field}, nil
+}
+
+func cutLast(str, sep string) (string, string, bool) {
+	idx := strings.LastIndex(str, sep)
+	if idx == -1 {
+		return str, "", false
+	}
+
+	return str[:idx], str[idx+len(sep):], true
+}
+
+func (p pattern) match(pkg, typ, field string) bool {
+	return (p.pkg == "" || p.pkg == pkg) && p.typ == typ && (p.field == "*" || p.field == field)
+}
+
+// selectFields returns fields of package-level structs matched by patterns.
+func selectFields(pkgs []*packages.Package, patterns []string) (map[fieldKey]target, []string, error) {
+	parsed := make([]pattern, len(patterns))
+	for i, str := range patterns {
+		p, err := parsePattern(str)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		parsed[i] = p
+	}
+
+	var (
+		fields   = make(map[fieldKey]target)
+		warnings []string
+		seen     = make(map[fieldKey]bool)
+	)
+
+	for _, pkg := range pkgs {
+		scope := pkg.Types.Scope()
+		for _, name := range scope.Names() {
+			obj, ok := scope.Lookup(name).(*types.TypeName)
+			if !ok || obj.IsAlias() {
+				continue
+			}
+
+			st, ok := obj.Type().Underlying().(*types.Struct)
+			if !ok {
+				continue
+			}
+
+			for i := range st.NumFields() {
+				fld := st.Field(i)
+				if !slices.ContainsFunc(parsed, func(p pattern) bool {
+					return p.match(pkg.PkgPath, name, fld.Name())
+				}) {
+					continue
+				}
+
+				pos := pkg.Fset.Position(fld.Pos())
+				key := fieldKey{filename: pos.Filename, offset: pos.Offset}
+
+				if seen[key] {
+					continue
+				}
+
+				seen[key] = true
+
+				tgt, ok := classify(fld.Type())
+				if ok && contains(tgt.elem, obj.Type(), nil) {
+					warnings = append(warnings, fmt.Sprintf("%s: %s.%s: optional.Val[%s] would be a recursive type",
+						pos, name, fld.Name(), tgt.elem))
+
+					continue
+				}
+
+				if !ok {
+					warnings = append(warnings, fmt.Sprintf("%s: %s.%s: unsupported type %s",
+						pos, name, fld.Name(), fld.Type()))
+
+					continue
+				}
+
+				fields[key] = tgt
+			}
+		}
+	}
+
+	return fields, warnings, nil
+}
+
+// contains reports whether values of typ contain values of target, so that
+// optional.Val[typ] can not be a field of target.
+func contains(typ, target types.Type, seen map[types.Type]bool) bool {
+	if types.Identical(typ, target) {
+		return true
+	}
+
+	if seen == nil {
+		seen = make(map[types.Type]bool)
+	}
+
+	if seen[typ] {
+		return false
+	}
+
+	seen[typ] = true
+
+	switch under := typ.Underlying().(type) {
+	case *types.Struct:
+		for i := range under.NumFields() {
+			if contains(under.Field(i).Type(), target, seen) {
+				return true
+			}
+		}
+	case *types.Array:
+		return contains(under.Elem(), target, seen)
+	}
+
+	return false
+}
+
+// classify returns the target of migration of type typ.
+func classify(typ types.Type) (target, bool) {
+	if ptr, ok := types.Unalias(typ).(*types.Pointer); ok {
+		return target{kind: kindPointer, elem: ptr.Elem(), name: "", pkg: nil, valueField: ""}, true
+	}
+
+	named, ok := types.Unalias(typ).(*types.Named)
+	if !ok || named.Obj().Pkg() == nil {
+		return target{}, false
+	}
+
+	var (
+		pkg  = named.Obj().Pkg()
+		name = named.Obj().Name()
+		res  = target{kind: 0, elem: nil, name: name, pkg: pkg, valueField: ""}
+	)
+
+	switch {
+	case pkg.Path() == sqlPath:
+		res.kind, res.valueField = kindSQL, sqlValueFields[name]
+	case slices.Contains(guregPaths, pkg.Path()):
+		res.kind, res.valueField = kindGureg, guregValueFields[name]
+	}
+
+	if res.valueField == "" {
+		return target{}, false
+	}
+
+	obj, _, _ := types.LookupFieldOrMethod(typ, false, pkg, res.valueField)
+
+	value, ok := obj.(*types.Var)
+	if !ok {
+		return target{}, false
+	}
+
+	res.elem = value.Type()
+
+	return res, true
+}
+
+// edit replaces the range of source code with text.
+type edit struct {
+	pos, end token.Pos
+	text     string
+	// seq orders insertions at the same position.
+	seq int
+	// closing insertions at the same position are applied in reverse order.
+	closing bool
+}
+
+// apply applies edits to src, adds required imports, removes unused imports
+// and formats the result.
+func (r *rewriter) apply(src []byte) ([]byte, error) {
+	tokFile := r.pkg.Fset.File(r.file.Pos())
+
+	edits := slices.Clone(r.edits)
+	slices.SortStableFunc(edits, func(a, b edit) int {
+		if a.pos != b.pos {
+			return int(a.pos - b.pos)
+		}
+
+		if a.closing != b.closing {
+			if a.closing {
+				return -1
+			}
+
+			return 1
+		}
+
+		if a.closing {
+			return b.seq - a.seq
+		}
+
+		return a.seq - b.seq
+	})
+
+	var (
+		buf  bytes.Buffer
+		last int
+	)
+
+	for _, e := range edits {
+		start, end := tokFile.Offset(e.pos), tokFile.Offset(e.end)
+		if start < last {
+			r.warnf(e.pos, "overlapping rewrite is skipped: %q", e.text)
+
+			continue
+		}
+
+		buf.Write(src[last:start])
+		buf.WriteString(e.text)
+		last = end
+	}
+
+	buf.Write(src[last:])
+
+	fset := token.NewFileSet()
+
+	file, err := parser.ParseFile(fset, tokFile.Name(), buf.Bytes(), parser.ParseComments)
+	if err != nil {
+		return nil, fmt.Errorf("parse rewritten file: %w", err)
+	}
+
+	for _, path := range r.imports {
+		if r.importNames[path] != packageName(path) {
+			astutil.AddNamedImport(fset, file, r.importNames[path], path)
+		} else {
+			astutil.AddImport(fset, file, path)
+		}
+	}
+
+	// Imports of original types may become unused.
+	for _, spec := range slices.Clone(file.Imports) {
+		path := strings.Trim(spec.Path.Value, `"`)
+		if path != sqlPath && !slices.Contains(guregPaths, path) {
+			continue
+		}
+
+		name := packageName(path)
+		if spec.Name != nil {
+			name = spec.Name.Name
+		}
+
+		if usesName(file, name) {
+			continue
+		}
+
+		if spec.Name != nil {
+			astutil.DeleteNamedImport(fset, file, spec.Name.Name, path)
+		} else {
+			astutil.DeleteImport(fset, file, path)
+		}
+	}
+
+	var out bytes.Buffer
+	if err := format.Node(&out, fset, file); err != nil {
+		return nil, fmt.Errorf("format rewritten file: %w", err)
+	}
+
+	// Group imports like goimports does.
+	res, err := imports.Process(tokFile.Name(), out.Bytes(), &imports.Options{ //nolint:exhaustruct
+		Comments:   true,
+		TabIndent:  true,
+		TabWidth:   8, //nolint:mnd
+		FormatOnly: true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("format imports: %w", err)
+	}
+
+	return res, nil
+}
+
+// usesName reports whether file refers to the package name.
+func usesName(file *ast.File, name string) bool {
+	found := false
+
+	ast.Inspect(file, func(node ast.Node) bool {
+		if sel, ok := node.(*ast.SelectorExpr); ok {
+			if ident, ok := sel.X.(*ast.Ident); ok && ident.Name == name {
+				found = true
+			}
+		}
+
+		return !found
+	})
+
+	return found
+}
+
+// packageName returns the default name of the package.
+func packageName(path string) string {
+	name := path[strings.LastIndexByte(path, '/')+1:]
+	if base, _, ok := strings.Cut(name, "."); ok {
+		// gopkg.in/guregu/null.v4
+		name = base
+	}
+
+	if strings.HasPrefix(name, "v") && strings.Trim(name[1:], "0123456789") == "" {
+		// github.com/guregu/null/v5
+		parent := path[:strings.LastIndexByte(path, '/')]
+		name = parent[strings.LastIndexByte(parent, '/')+1:]
+	}
+
+	return name
+}
+
+// exprString returns source code of the expression.
+func exprString(expr ast.Expr) string {
+	return types.ExprString(expr)
+}
@@ -0,0 +1,217 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/packages"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+func TestMigrate(t *testing.T) {
+	t.Parallel()
+
+	table := []struct {
+		name     string
+		fields   []string
+		patterns []string
+		warnings []string
+	}{
+		{
+			name:     "pointer",
+			fields:   []string{"User.Nickname", "User.Age", "example.com/migrate/pointer.User.Address", "User.Manager"},
+			patterns: []string{"./pointer/..."},
+			warnings: []string{
+				"pointer.go:21:2: User.Manager: optional.Val[example.com/migrate/pointer.User] would be a recursive type",
+				"pointer.go:42:40: dereference of u.Nickname returns zero value instead of panic when it is nil",
+				"pointer.go:49:15: nickname is copied, writes through the pointer do not change the field",
+				"pointer.go:51:2: assignment of *u.Age does not change other pointers to the value",
+				"pointer.go:57:3: method u.Address.Normalize has pointer receiver and must be migrated manually",
+				"pointer.go:58:3: assignment of u.Address.City must be migrated manually",
+				"pointer.go:61:10: u.Nickname.AsPointer() returns a copy, writes through it do not change the field",
+				"pointer.go:66:12: src.Age is copied, the fields do not share the value anymore",
+				"pointer.go:67:17: src.Nickname is copied, the fields do not share the value anymore",
+				"pointer.go:75:12: src.Age is copied, the fields do not share the value anymore",
+				"pointer.go:76:2: assignment of *src.Age does not change other pointers to the value",
+				"pointer.go:77:14: dereference of dst.Age returns zero value instead of panic when it is nil",
+				"pointer_test.go:7:5: dereference of u.Nickname returns zero value instead of panic when it is nil",
+				"pointer_test.go:8:11: dereference of u.Nickname returns zero value instead of panic when it is nil",
+			},
+		},
+		{
+			name:     "sqlnull",
+			fields:   []string{"Account.Email", "Account.DeletedAt", "Account.Score"},
+			patterns: []string{"./sqlnull"},
+			warnings: []string{
+				"sqlnull.go:38:2: assignment of a.Email.String is migrated to a.Email.Set and makes empty value present",
+			},
+		},
+		{
+			name:     "guregu",
+			fields:   []string{"Profile.*"},
+			patterns: []string{"./guregu"},
+			warnings: []string{
+				"guregu.go:38:9: call of p.Bio.Equal must be migrated manually",
+			},
+		},
+	}
+
+	for _, row := range table {
+		t.Run(row.name, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := Migrate(Config{Dir: "testdata", Fields: row.fields, Tags: nil}, row.patterns...)
+			require.NoError(t, err)
+
+			var warnings []string
+			for _, warning := range res.Warnings {
+				prefix := mustAbs(t, filepath.Join("testdata", row.name)) + string(filepath.Separator)
+				warnings = append(warnings, strings.TrimPrefix(warning, prefix))
+			}
+
+			assert.Equal(t, row.warnings, warnings)
+
+			overlay := make(map[string][]byte)
+
+			for filename, src := range res.Files {
+				golden := filename + ".golden"
+				if *update {
+					require.NoError(t, os.WriteFile(golden, src, 0o644)) //nolint:gosec
+				}
+
+				expected, err := os.ReadFile(golden)
+				require.NoError(t, err)
+				assert.Equal(t, string(expected), string(src), filename)
+
+				overlay[filename] = src
+			}
+
+			// All files with golden output are rewritten.
+			goldens, err := filepath.Glob(filepath.Join("testdata", row.name, "*", "*.golden"))
+			require.NoError(t, err)
+
+			more, err := filepath.Glob(filepath.Join("testdata", row.name, "*.golden"))
+			require.NoError(t, err)
+
+			for _, golden := range slices.Concat(goldens, more) {
+				assert.Contains(t, res.Files, mustAbs(t, strings.TrimSuffix(golden, ".golden")))
+			}
+
+			// Rewritten code compiles.
+			pkgs, err := packages.Load(&packages.Config{ //nolint:exhaustruct
+				Mode:    packages.NeedName | packages.NeedTypes | packages.NeedImports | packages.NeedDeps,
+				Dir:     "testdata",
+				Tests:   true,
+				Overlay: overlay,
+			}, row.patterns...)
+			require.NoError(t, err)
+
+			// Except for lines reported as warnings.
+			warned := make(map[string]bool)
+			for _, warning := range res.Warnings {
+				file, rest, _ := strings.Cut(warning, ":")
+				line, _, _ := strings.Cut(rest, ":")
+				warned[file+":"+line] = true
+			}
+
+			for _, pkg := range pkgs {
+				for _, pkgErr := range pkg.Errors {
+					file, rest, _ := strings.Cut(pkgErr.Pos, ":")
+					line, _, _ := strings.Cut(rest, ":")
+					assert.True(t, warned[file+":"+originalLine(t, file, overlay[file], line)], pkgErr.Error())
+				}
+			}
+		})
+	}
+}
+
+func TestMigrate_Errors(t *testing.T) {
+	t.Parallel()
+
+	_, err := Migrate(Config{Dir: "testdata", Fields: nil, Tags: nil}, "./pointer")
+	require.ErrorIs(t, err, errNoFields)
+
+	_, err = Migrate(Config{Dir: "testdata", Fields: []string{"User"}, Tags: nil}, "./pointer")
+	require.ErrorIs(t, err, errInvalidPattern)
+
+	_, err = Migrate(Config{Dir: "testdata", Fields: []string{"User.Missing"}, Tags: nil}, "./pointer")
+	require.ErrorIs(t, err, errNoFields)
+}
+
+func TestMigrate_UnsupportedType(t *testing.T) {
+	t.Parallel()
+
+	res, err := Migrate(Config{Dir: "testdata", Fields: []string{"User.ID", "User.Age"}, Tags: nil}, "./pointer")
+	require.NoError(t, err)
+	require.NotEmpty(t, res.Warnings)
+	assert.Contains(t, res.Warnings[0], "User.ID: unsupported type int64")
+}
+
+func TestParsePattern(t *testing.T) {
+	t.Parallel()
+
+	table := []struct {
+		in  string
+		exp pattern
+	}{
+		{in: "User.Name", exp: pattern{pkg: "", typ: "User", field: "Name"}},
+		{in: "User.*", exp: pattern{pkg: "", typ: "User", field: "*"}},
+		{in: "example.com/a/b.User.Name", exp: pattern{pkg: "example.com/a/b", typ: "User", field: "Name"}},
+	}
+
+	for _, row := range table {
+		res, err := parsePattern(row.in)
+		require.NoError(t, err)
+		assert.Equal(t, row.exp, res)
+	}
+
+	for _, in := range []string{"User", ".Name", "User."} {
+		_, err := parsePattern(in)
+		require.ErrorIs(t, err, errInvalidPattern)
+	}
+}
+
+func TestPackageName(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "optional", packageName("github.com/kazhuravlev/optional"))
+	assert.Equal(t, "null", packageName("gopkg.in/guregu/null.v4"))
+	assert.Equal(t, "null", packageName("github.com/guregu/null/v5"))
+	assert.Equal(t, "sql", packageName("database/sql"))
+}
+
+// originalLine maps the line of the rewritten file to the line of the
+// original one. Rewrites keep lines of the file except for added imports.
+func originalLine(t *testing.T, filename string, rewritten []byte, line string) string {
+	t.Helper()
+
+	num, err := strconv.Atoi(line)
+	require.NoError(t, err)
+
+	if rewritten == nil {
+		return line
+	}
+
+	src, err := os.ReadFile(filename)
+	require.NoError(t, err)
+
+	return strconv.Itoa(num - bytes.Count(rewritten, []byte("\n")) + bytes.Count(src, []byte("\n")))
+}
+
+func mustAbs(t *testing.T, path string) string {
+	t.Helper()
+
+	res, err := filepath.Abs(path)
+	require.NoError(t, err)
+
+	return res
+}
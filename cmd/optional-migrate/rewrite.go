@@ -0,0 +1,990 @@
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// Methods of guregu/null types and their replacements in optional.Val.
+var guregMethods = map[string]string{
+	"ValueOrZero": "Val",
+	"Ptr":         "AsPointer",
+	"SetValid":    "Set",
+	"IsValid":     "HasVal",
+}
+
+// Methods which exist in both original types and optional.Val.
+var sharedMethods = map[string]bool{
+	"Scan":          true,
+	"Value":         true,
+	"MarshalJSON":   true,
+	"UnmarshalJSON": true,
+}
+
+// Methods of original types which do not change the value.
+var readMethods = map[string]bool{
+	"Value":       true,
+	"MarshalJSON": true,
+	"ValueOrZero": true,
+	"Ptr":         true,
+	"IsZero":      true,
+	"IsValid":     true,
+}
+
+// rewriter collects edits of one file.
+type rewriter struct {
+	pkg    *packages.Package
+	file   *ast.File
+	fields map[fieldKey]target
+
+	edits    []edit
+	warnings []string
+
+	// subst maps fields to variables declared by `if v, ok := f.Get(); ok`.
+	subst map[string]string
+	// guards counts checks of pointer fields for nil which enclose the
+	// current node.
+	guards map[string]int
+
+	// fileNames maps import paths to names of imports of the file.
+	fileNames map[string]string
+	// imports are paths of packages which must be imported.
+	imports     []string
+	importNames map[string]string
+}
+
+func newRewriter(pkg *packages.Package, file *ast.File, fields map[fieldKey]target) *rewriter {
+	rw := &rewriter{
+		pkg:         pkg,
+		file:        file,
+		fields:      fields,
+		edits:       nil,
+		warnings:    nil,
+		subst:       make(map[string]string),
+		guards:      make(map[string]int),
+		fileNames:   make(map[string]string),
+		imports:     nil,
+		importNames: make(map[string]string),
+	}
+
+	for _, spec := range file.Imports {
+		path, _ := strconv.Unquote(spec.Path.Value)
+
+		switch {
+		case spec.Name == nil:
+			if obj, ok := pkg.TypesInfo.Implicits[spec].(*types.PkgName); ok {
+				rw.fileNames[path] = obj.Name()
+			}
+		case spec.Name.Name != "_" && spec.Name.Name != ".":
+			rw.fileNames[path] = spec.Name.Name
+		}
+	}
+
+	return rw
+}
+
+func (r *rewriter) warnf(pos token.Pos, format string, args ...any) {
+	r.warnings = append(r.warnings, r.pkg.Fset.Position(pos).String()+": "+fmt.Sprintf(format, args...))
+}
+
+func (r *rewriter) replace(node ast.Node, text string) {
+	r.edits = append(r.edits, edit{pos: node.Pos(), end: node.End(), text: text, seq: len(r.edits), closing: false})
+}
+
+func (r *rewriter) replaceRange(pos, end token.Pos, text string) {
+	r.edits = append(r.edits, edit{pos: pos, end: end, text: text, seq: len(r.edits), closing: false})
+}
+
+// wrap inserts prefix before node and suffix after it.
+func (r *rewriter) wrap(node ast.Node, prefix, suffix string) {
+	r.edits = append(r.edits,
+		edit{pos: node.Pos(), end: node.Pos(), text: prefix, seq: len(r.edits), closing: false},
+		edit{pos: node.End(), end: node.End(), text: suffix, seq: len(r.edits) + 1, closing: true},
+	)
+}
+
+// qualifier returns the name of package in the file and records imports
+// which must be added.
+func (r *rewriter) qualifier(pkg *types.Package) string {
+	if pkg.Path() == r.pkg.Types.Path() {
+		return ""
+	}
+
+	if name, ok := r.fileNames[pkg.Path()]; ok {
+		return name
+	}
+
+	if name, ok := r.importNames[pkg.Path()]; ok {
+		return name
+	}
+
+	r.imports = append(r.imports, pkg.Path())
+	r.importNames[pkg.Path()] = pkg.Name()
+
+	return pkg.Name()
+}
+
+// optional returns the name of optional package in the file.
+func (r *rewriter) optional() string {
+	return r.qualifier(types.NewPackage(optionalPath, "optional"))
+}
+
+func (r *rewriter) walk(node ast.Node) {
+	if node == nil {
+		return
+	}
+
+	ast.Inspect(node, func(node ast.Node) bool {
+		if node == nil {
+			return false
+		}
+
+		return r.visit(node)
+	})
+}
+
+// fieldOf returns the target of expression which selects a migrated field.
+func (r *rewriter) fieldOf(expr ast.Expr) (*ast.SelectorExpr, target, bool) {
+	sel, ok := ast.Unparen(expr).(*ast.SelectorExpr)
+	if !ok {
+		return nil, target{}, false
+	}
+
+	selection := r.pkg.TypesInfo.Selections[sel]
+	if selection == nil || selection.Kind() != types.FieldVal {
+		return nil, target{}, false
+	}
+
+	tgt, ok := r.target(selection.Obj())
+
+	return sel, tgt, ok
+}
+
+func (r *rewriter) target(obj types.Object) (target, bool) {
+	if obj == nil {
+		return target{}, false
+	}
+
+	pos := r.pkg.Fset.Position(obj.Pos())
+	tgt, ok := r.fields[fieldKey{filename: pos.Filename, offset: pos.Offset}]
+
+	return tgt, ok
+}
+
+// valType returns optional.Val[T] of the target.
+func (r *rewriter) valType(tgt target) string {
+	return r.optional() + ".Val[" + types.TypeString(tgt.elem, r.qualifier) + "]"
+}
+
+//nolint:cyclop,funlen
+func (r *rewriter) visit(node ast.Node) bool {
+	switch node := node.(type) {
+	case *ast.Field:
+		r.rewriteField(node)
+
+		return false
+	case *ast.BlockStmt:
+		r.walkBlock(node.List)
+
+		return false
+	case *ast.IfStmt:
+		if !r.rewriteIf(node) {
+			r.walkIf(node)
+		}
+
+		return false
+	case *ast.AssignStmt:
+		return !r.rewriteAssign(node)
+	case *ast.IncDecStmt:
+		if sel, _, ok := r.valueOf(node.X); ok {
+			r.warnf(node.Pos(), "%s of %s must be migrated manually", node.Tok, exprString(sel))
+
+			return false
+		}
+	case *ast.KeyValueExpr:
+		ident, ok := node.Key.(*ast.Ident)
+		if !ok {
+			return true
+		}
+
+		if tgt, ok := r.target(r.pkg.TypesInfo.Uses[ident]); ok {
+			r.convertIn(node.Value, tgt)
+
+			return false
+		}
+	case *ast.BinaryExpr:
+		if node.Op == token.LAND {
+			r.walk(node.X)
+
+			release := r.guard(r.presentKeys(node.X))
+			r.walk(node.Y)
+			release()
+
+			return false
+		}
+
+		return !r.rewriteNilCheck(node)
+	case *ast.UnaryExpr:
+		if node.Op != token.AND {
+			return true
+		}
+
+		if sel, _, ok := r.fieldOf(node.X); ok {
+			// &f is used to scan values, optional.Val implements sql.Scanner.
+			r.walk(sel.X)
+
+			return false
+		}
+	case *ast.StarExpr:
+		sel, tgt, ok := r.fieldOf(node.X)
+		if !ok || tgt.kind != kindPointer {
+			return true
+		}
+
+		r.replace(node, r.deref(node.Pos(), sel))
+		r.walk(sel.X)
+
+		return false
+	case *ast.CallExpr:
+		return !r.rewriteMethodCall(node)
+	case *ast.SelectorExpr:
+		return !r.rewriteSelector(node)
+	}
+
+	return true
+}
+
+// rewriteField changes type of the field declaration.
+func (r *rewriter) rewriteField(field *ast.Field) {
+	var (
+		matched int
+		tgt     target
+	)
+
+	for _, name := range field.Names {
+		if t, ok := r.target(r.pkg.TypesInfo.Defs[name]); ok {
+			matched++
+			tgt = t
+		}
+	}
+
+	switch {
+	case matched == 0:
+		r.walk(field.Type)
+	case matched != len(field.Names):
+		r.warnf(field.Pos(), "fields declared together must be migrated together")
+	default:
+		r.replace(field.Type, r.valType(tgt))
+	}
+}
+
+// value returns the expression of the value of the field.
+func (r *rewriter) value(sel *ast.SelectorExpr) string {
+	if name, ok := r.subst[exprString(sel)]; ok {
+		return name
+	}
+
+	return exprString(sel) + ".Val()"
+}
+
+// deref returns the value of the pointer field and reports dereferences
+// which are not checked for nil.
+func (r *rewriter) deref(pos token.Pos, field *ast.SelectorExpr) string {
+	key := exprString(field)
+	if _, ok := r.subst[key]; !ok && r.guards[key] == 0 {
+		r.warnf(pos, "dereference of %s returns zero value instead of panic when it is nil", key)
+	}
+
+	return r.value(field)
+}
+
+// guard marks fields as checked for nil until release is called.
+func (r *rewriter) guard(keys []string) (release func()) {
+	for _, key := range keys {
+		r.guards[key]++
+	}
+
+	return func() {
+		for _, key := range keys {
+			r.guards[key]--
+		}
+	}
+}
+
+// presentKeys returns pointer fields which are not nil when expression
+// `f != nil && g != nil` is true.
+func (r *rewriter) presentKeys(expr ast.Expr) []string {
+	bin, ok := ast.Unparen(expr).(*ast.BinaryExpr)
+	if !ok {
+		return nil
+	}
+
+	switch bin.Op {
+	case token.LAND:
+		return append(r.presentKeys(bin.X), r.presentKeys(bin.Y)...)
+	case token.NEQ:
+		if field, _, ok := r.nilCheck(bin); ok {
+			return []string{exprString(field)}
+		}
+	}
+
+	return nil
+}
+
+// walkIf walks the if statement, fields checked by its condition are not nil
+// in the body until they are assigned.
+func (r *rewriter) walkIf(stmt *ast.IfStmt) {
+	r.walk(stmt.Init)
+	r.walk(stmt.Cond)
+
+	keys := slices.DeleteFunc(r.presentKeys(stmt.Cond), func(key string) bool {
+		return assigns(stmt.Body, key)
+	})
+
+	release := r.guard(keys)
+	r.walk(stmt.Body)
+	release()
+
+	r.walk(stmt.Else)
+}
+
+// walkBlock walks statements of the block, fields are not nil after
+// `if f == nil { return }` until they are assigned.
+func (r *rewriter) walkBlock(list []ast.Stmt) {
+	for i, stmt := range list {
+		r.walk(stmt)
+
+		key, ok := r.exitsIfNil(stmt)
+		if !ok {
+			continue
+		}
+
+		rest := list[i+1:]
+		if slices.ContainsFunc(rest, func(stmt ast.Stmt) bool { return assigns(stmt, key) }) {
+			continue
+		}
+
+		release := r.guard([]string{key})
+		r.walkBlock(rest)
+		release()
+
+		return
+	}
+}
+
+// exitsIfNil returns the field of statement `if f == nil { return }`.
+func (r *rewriter) exitsIfNil(stmt ast.Stmt) (string, bool) {
+	ifStmt, ok := stmt.(*ast.IfStmt)
+	if !ok || ifStmt.Init != nil || ifStmt.Else != nil || len(ifStmt.Body.List) == 0 {
+		return "", false
+	}
+
+	bin, ok := ast.Unparen(ifStmt.Cond).(*ast.BinaryExpr)
+	if !ok || bin.Op != token.EQL {
+		return "", false
+	}
+
+	field, _, ok := r.nilCheck(bin)
+	if !ok {
+		return "", false
+	}
+
+	switch last := ifStmt.Body.List[len(ifStmt.Body.List)-1].(type) {
+	case *ast.ReturnStmt, *ast.BranchStmt:
+		return exprString(field), true
+	case *ast.ExprStmt:
+		call, ok := last.X.(*ast.CallExpr)
+		if !ok {
+			return "", false
+		}
+
+		if ident, ok := call.Fun.(*ast.Ident); ok && r.pkg.TypesInfo.Uses[ident] == types.Universe.Lookup("panic") {
+			return exprString(field), true
+		}
+	}
+
+	return "", false
+}
+
+// valueOf returns the field of expressions *f and f.String.
+func (r *rewriter) valueOf(expr ast.Expr) (*ast.SelectorExpr, target, bool) {
+	switch expr := ast.Unparen(expr).(type) {
+	case *ast.StarExpr:
+		if sel, tgt, ok := r.fieldOf(expr.X); ok && tgt.kind == kindPointer {
+			return sel, tgt, true
+		}
+	case *ast.SelectorExpr:
+		if sel, tgt, ok := r.fieldOf(expr.X); ok && tgt.kind != kindPointer && expr.Sel.Name == tgt.valueField {
+			return sel, tgt, true
+		}
+	}
+
+	return nil, target{}, false
+}
+
+// reads reports whether node reads the value of the field key.
+func (r *rewriter) reads(node ast.Node, key string) bool {
+	found := false
+
+	ast.Inspect(node, func(node ast.Node) bool {
+		if expr, ok := node.(ast.Expr); ok {
+			if sel, _, ok := r.valueOf(expr); ok && exprString(sel) == key {
+				found = true
+			}
+		}
+
+		return !found
+	})
+
+	return found
+}
+
+// validOf returns the field of expression f.Valid.
+func (r *rewriter) validOf(expr ast.Expr) (*ast.SelectorExpr, bool) {
+	sel, ok := ast.Unparen(expr).(*ast.SelectorExpr)
+	if !ok || sel.Sel.Name != "Valid" {
+		return nil, false
+	}
+
+	field, tgt, ok := r.fieldOf(sel.X)
+	if !ok || tgt.kind == kindPointer {
+		return nil, false
+	}
+
+	return field, true
+}
+
+// presenceOf returns the field of expressions `f != nil` and `f.Valid`.
+func (r *rewriter) presenceOf(expr ast.Expr) (*ast.SelectorExpr, bool) {
+	if field, ok := r.validOf(expr); ok {
+		return field, true
+	}
+
+	bin, ok := ast.Unparen(expr).(*ast.BinaryExpr)
+	if !ok || bin.Op != token.NEQ {
+		return nil, false
+	}
+
+	field, _, ok := r.nilCheck(bin)
+
+	return field, ok
+}
+
+// nilCheck returns the field of expressions `f == nil` and `f != nil`.
+func (r *rewriter) nilCheck(bin *ast.BinaryExpr) (*ast.SelectorExpr, target, bool) {
+	if bin.Op != token.EQL && bin.Op != token.NEQ {
+		return nil, target{}, false
+	}
+
+	for _, pair := range [][2]ast.Expr{{bin.X, bin.Y}, {bin.Y, bin.X}} {
+		if !isNil(pair[1]) {
+			continue
+		}
+
+		if sel, tgt, ok := r.fieldOf(pair[0]); ok && tgt.kind == kindPointer {
+			return sel, tgt, true
+		}
+	}
+
+	return nil, target{}, false
+}
+
+func isNil(expr ast.Expr) bool {
+	ident, ok := ast.Unparen(expr).(*ast.Ident)
+
+	return ok && ident.Name == "nil"
+}
+
+// rewriteNilCheck rewrites `f != nil` to `f.HasVal()`.
+func (r *rewriter) rewriteNilCheck(bin *ast.BinaryExpr) bool {
+	sel, _, ok := r.nilCheck(bin)
+	if !ok {
+		return false
+	}
+
+	if bin.Op == token.EQL {
+		r.replace(bin, "!"+exprString(sel)+".HasVal()")
+	} else {
+		r.replace(bin, exprString(sel)+".HasVal()")
+	}
+
+	r.walk(sel.X)
+
+	return true
+}
+
+// rewriteIf rewrites `if f != nil { use(*f) }` to
+// `if v, ok := f.Get(); ok { use(v) }`.
+func (r *rewriter) rewriteIf(stmt *ast.IfStmt) bool {
+	if stmt.Init != nil {
+		return false
+	}
+
+	field, ok := r.presenceOf(stmt.Cond)
+	if !ok {
+		return false
+	}
+
+	key := exprString(field)
+	if _, ok := r.subst[key]; ok || writes(stmt.Body, key) || !r.reads(stmt.Body, key) {
+		return false
+	}
+
+	used := identNames(stmt.Body)
+	for name := range identNames(field) {
+		used[name] = true
+	}
+
+	valName := uniqueName(lowerFirst(field.Sel.Name), used)
+	used[valName] = true
+	okName := uniqueName("ok", used)
+
+	r.replace(stmt.Cond, fmt.Sprintf("%s, %s := %s.Get(); %s", valName, okName, key, okName))
+
+	r.subst[key] = valName
+	r.walk(stmt.Body)
+	delete(r.subst, key)
+
+	r.walk(stmt.Else)
+
+	return true
+}
+
+// rewriteAssign rewrites assignments of fields and their values.
+func (r *rewriter) rewriteAssign(stmt *ast.AssignStmt) bool {
+	if len(stmt.Lhs) != len(stmt.Rhs) {
+		for _, lhs := range stmt.Lhs {
+			if sel, _, ok := r.fieldOf(lhs); ok {
+				r.warnf(lhs.Pos(), "assignment of %s must be migrated manually", exprString(sel))
+			}
+		}
+
+		return false
+	}
+
+	if len(stmt.Lhs) != 1 || stmt.Tok != token.ASSIGN {
+		return false
+	}
+
+	lhs, rhs := stmt.Lhs[0], stmt.Rhs[0]
+
+	if sel, tgt, ok := r.fieldOf(lhs); ok {
+		if isNil(rhs) && tgt.kind == kindPointer {
+			r.replace(stmt, exprString(sel)+".Reset()")
+
+			return true
+		}
+
+		r.walk(sel.X)
+		r.convertIn(rhs, tgt)
+
+		return true
+	}
+
+	if sel, tgt, ok := r.valueOf(lhs); ok {
+		if tgt.kind == kindPointer {
+			// The field does not share the value with other pointers.
+			r.warnf(stmt.Pos(), "assignment of %s does not change other pointers to the value", exprString(lhs))
+		} else {
+			// Assignment of the value keeps Valid as is, Set makes NULL present.
+			r.warnf(stmt.Pos(), "assignment of %s is migrated to %s.Set and makes empty value present",
+				exprString(lhs), exprString(sel))
+		}
+
+		r.replaceRange(lhs.Pos(), rhs.Pos(), exprString(sel)+".Set(")
+		r.wrap(rhs, "", ")")
+		r.walk(rhs)
+
+		return true
+	}
+
+	if sel, ok := ast.Unparen(lhs).(*ast.SelectorExpr); ok {
+		if field, tgt, ok := r.fieldOf(sel.X); ok && tgt.kind == kindPointer {
+			r.warnf(stmt.Pos(), "assignment of %s must be migrated manually", exprString(sel))
+			r.walk(field.X)
+			r.walk(rhs)
+
+			return true
+		}
+	}
+
+	if sel, ok := r.validOf(lhs); ok {
+		if ident, ok := ast.Unparen(rhs).(*ast.Ident); ok && ident.Name == "false" {
+			r.replace(stmt, exprString(sel)+".Reset()")
+		} else {
+			r.warnf(stmt.Pos(), "assignment of %s.Valid must be migrated manually", exprString(sel))
+		}
+
+		return true
+	}
+
+	return false
+}
+
+// rewriteMethodCall rewrites calls of methods of sql and guregu/null types.
+func (r *rewriter) rewriteMethodCall(call *ast.CallExpr) bool {
+	fun, ok := ast.Unparen(call.Fun).(*ast.SelectorExpr)
+	if !ok {
+		return false
+	}
+
+	field, tgt, ok := r.fieldOf(fun.X)
+	if !ok || tgt.kind == kindPointer {
+		return false
+	}
+
+	name := fun.Sel.Name
+
+	switch {
+	case sharedMethods[name]:
+	case tgt.kind == kindGureg && guregMethods[name] != "":
+		r.replace(fun.Sel, guregMethods[name])
+	case tgt.kind == kindGureg && name == "IsZero":
+		r.replace(call, "!"+exprString(field)+".HasVal()")
+
+		return true
+	default:
+		r.warnf(call.Pos(), "call of %s.%s must be migrated manually", exprString(field), name)
+	}
+
+	r.walk(field.X)
+
+	for _, arg := range call.Args {
+		r.walk(arg)
+	}
+
+	return true
+}
+
+// rewriteSelector rewrites reads of fields and their values.
+func (r *rewriter) rewriteSelector(sel *ast.SelectorExpr) bool {
+	if field, ok := r.validOf(sel); ok {
+		key := exprString(field)
+		if _, ok := r.subst[key]; ok {
+			r.replace(sel, "true")
+		} else {
+			r.replace(sel, key+".HasVal()")
+		}
+
+		r.walk(field.X)
+
+		return true
+	}
+
+	if field, _, ok := r.valueOf(sel); ok {
+		r.replace(sel, r.value(field))
+		r.walk(field.X)
+
+		return true
+	}
+
+	if field, tgt, ok := r.fieldOf(sel.X); ok && tgt.kind == kindPointer {
+		// Fields and methods of *T.
+		_, isSubst := r.subst[exprString(field)]
+		if selection := r.pkg.TypesInfo.Selections[sel]; !isSubst && selection != nil && hasPointerRecv(selection) {
+			r.warnf(sel.Pos(), "method %s has pointer receiver and must be migrated manually", exprString(sel))
+		}
+
+		r.replace(field, r.deref(sel.Pos(), field))
+		r.walk(field.X)
+
+		return true
+	}
+
+	if field, tgt, ok := r.fieldOf(sel); ok {
+		r.convertOut(field, tgt)
+		r.walk(field.X)
+
+		return true
+	}
+
+	return false
+}
+
+// convertIn converts expression of original type to optional.Val.
+func (r *rewriter) convertIn(expr ast.Expr, tgt target) {
+	if field, other, ok := r.fieldOf(expr); ok && types.Identical(other.elem, tgt.elem) {
+		// Both fields are migrated.
+		if tgt.kind == kindPointer {
+			r.warnf(expr.Pos(), "%s is copied, the fields do not share the value anymore", exprString(field))
+		}
+
+		r.walk(field.X)
+
+		return
+	}
+
+	switch tgt.kind {
+	case kindPointer:
+		r.convertPointerIn(expr, tgt)
+	case kindSQL:
+		if r.convertLiteralIn(expr, tgt) {
+			return
+		}
+
+		if tgt.name == "Null" {
+			r.wrap(expr, r.optional()+".FromSQLNull(", ")")
+		} else {
+			r.wrap(expr, r.optional()+".From"+tgt.name+"(", ")")
+		}
+
+		r.walk(expr)
+	case kindGureg:
+		if r.convertConstructorIn(expr, tgt) {
+			return
+		}
+
+		r.wrap(expr, r.optional()+".NewFromPointer(", ".Ptr())")
+		r.walk(expr)
+	}
+}
+
+func (r *rewriter) convertPointerIn(expr ast.Expr, tgt target) {
+	switch {
+	case isNil(expr):
+		r.replace(expr, r.optional()+".Empty["+types.TypeString(tgt.elem, r.qualifier)+"]()")
+	case isAddr(expr):
+		unary := ast.Unparen(expr).(*ast.UnaryExpr) //nolint:forcetypeassert
+		r.replaceRange(unary.Pos(), unary.X.Pos(), r.optional()+".New(")
+		r.wrap(unary.X, "", ")")
+		r.walk(unary.X)
+	default:
+		r.warnf(expr.Pos(), "%s is copied, writes through the pointer do not change the field", exprString(expr))
+		r.wrap(expr, r.optional()+".NewFromPointer(", ")")
+		r.walk(expr)
+	}
+}
+
+// hasPointerRecv reports whether the selection is a method with pointer receiver.
+func hasPointerRecv(selection *types.Selection) bool {
+	fn, ok := selection.Obj().(*types.Func)
+	if !ok {
+		return false
+	}
+
+	_, ok = fn.Signature().Recv().Type().(*types.Pointer)
+
+	return ok
+}
+
+func isAddr(expr ast.Expr) bool {
+	unary, ok := ast.Unparen(expr).(*ast.UnaryExpr)
+
+	return ok && unary.Op == token.AND
+}
+
+// convertLiteralIn rewrites sql.NullString{String: s, Valid: true} to
+// optional.New(s).
+func (r *rewriter) convertLiteralIn(expr ast.Expr, tgt target) bool {
+	lit, ok := ast.Unparen(expr).(*ast.CompositeLit)
+	if !ok {
+		return false
+	}
+
+	var (
+		value ast.Expr
+		valid ast.Expr
+	)
+
+	for _, elt := range lit.Elts {
+		kv, ok := elt.(*ast.KeyValueExpr)
+		if !ok {
+			return false
+		}
+
+		ident, ok := kv.Key.(*ast.Ident)
+		if !ok {
+			return false
+		}
+
+		switch ident.Name {
+		case tgt.valueField:
+			value = kv.Value
+		case "Valid":
+			valid = kv.Value
+		}
+	}
+
+	switch {
+	case valid == nil:
+		r.replace(lit, r.optional()+".Empty["+types.TypeString(tgt.elem, r.qualifier)+"]()")
+	case value != nil && isTrue(valid):
+		r.replaceRange(lit.Pos(), value.Pos(), r.optional()+".New(")
+		r.replaceRange(value.End(), lit.End(), ")")
+		r.walk(value)
+	default:
+		return false
+	}
+
+	return true
+}
+
+func isTrue(expr ast.Expr) bool {
+	ident, ok := ast.Unparen(expr).(*ast.Ident)
+
+	return ok && ident.Name == "true"
+}
+
+// convertConstructorIn rewrites null.StringFrom(s) to optional.New(s) and
+// null.StringFromPtr(p) to optional.NewFromPointer(p).
+func (r *rewriter) convertConstructorIn(expr ast.Expr, tgt target) bool {
+	call, ok := ast.Unparen(expr).(*ast.CallExpr)
+	if !ok {
+		return false
+	}
+
+	var fun *ast.Ident
+
+	switch f := call.Fun.(type) {
+	case *ast.SelectorExpr:
+		fun = f.Sel
+	case *ast.IndexExpr:
+		if sel, ok := f.X.(*ast.SelectorExpr); ok {
+			fun = sel.Sel
+		}
+	}
+
+	if fun == nil {
+		return false
+	}
+
+	obj, ok := r.pkg.TypesInfo.Uses[fun].(*types.Func)
+	if !ok || obj.Pkg() != tgt.pkg {
+		return false
+	}
+
+	switch obj.Name() {
+	case tgt.name + "From":
+		r.replace(call.Fun, r.optional()+".New")
+	case tgt.name + "FromPtr":
+		if len(call.Args) == 1 && isNil(call.Args[0]) {
+			r.replace(call, r.optional()+".Empty["+types.TypeString(tgt.elem, r.qualifier)+"]()")
+
+			return true
+		}
+
+		r.replace(call.Fun, r.optional()+".NewFromPointer")
+	default:
+		return false
+	}
+
+	for _, arg := range call.Args {
+		r.walk(arg)
+	}
+
+	return true
+}
+
+// convertOut converts the migrated field to the original type where the
+// value of the field is used as is.
+func (r *rewriter) convertOut(field *ast.SelectorExpr, tgt target) {
+	key := exprString(field)
+
+	switch tgt.kind {
+	case kindPointer:
+		r.warnf(field.Pos(), "%s.AsPointer() returns a copy, writes through it do not change the field", key)
+		r.replace(field, key+".AsPointer()")
+	case kindSQL:
+		if tgt.name == "Null" {
+			r.replace(field, key+".SQLNull()")
+		} else {
+			r.replace(field, r.optional()+".To"+tgt.name+"("+key+")")
+		}
+	case kindGureg:
+		r.replace(field, r.qualifier(tgt.pkg)+"."+tgt.name+"FromPtr("+key+".AsPointer())")
+	}
+}
+
+// writes reports whether node assigns the expression key or takes its address.
+func writes(node ast.Node, key string) bool {
+	found := false
+
+	isKey := func(expr ast.Expr) bool {
+		str := exprString(ast.Unparen(expr))
+
+		return str == key || str == "*"+key || strings.HasPrefix(str, key+".")
+	}
+
+	ast.Inspect(node, func(node ast.Node) bool {
+		switch node := node.(type) {
+		case *ast.AssignStmt:
+			for _, lhs := range node.Lhs {
+				found = found || isKey(lhs)
+			}
+		case *ast.IncDecStmt:
+			found = found || isKey(node.X)
+		case *ast.UnaryExpr:
+			found = found || node.Op == token.AND && isKey(node.X)
+		case *ast.CallExpr:
+			if sel, ok := node.Fun.(*ast.SelectorExpr); ok && isKey(sel.X) {
+				// Methods may change the value.
+				found = found || !readMethods[sel.Sel.Name]
+			}
+		}
+
+		return !found
+	})
+
+	return found
+}
+
+// assigns reports whether node assigns the expression key or its parent.
+func assigns(node ast.Node, key string) bool {
+	found := false
+
+	ast.Inspect(node, func(node ast.Node) bool {
+		if stmt, ok := node.(*ast.AssignStmt); ok {
+			for _, lhs := range stmt.Lhs {
+				str := exprString(ast.Unparen(lhs))
+				found = found || str == key || strings.HasPrefix(key, str+".")
+			}
+		}
+
+		return !found
+	})
+
+	return found
+}
+
+// identNames returns names of all identifiers of node.
+func identNames(node ast.Node) map[string]bool {
+	names := make(map[string]bool)
+
+	ast.Inspect(node, func(node ast.Node) bool {
+		if ident, ok := node.(*ast.Ident); ok {
+			names[ident.Name] = true
+		}
+
+		return true
+	})
+
+	return names
+}
+
+func uniqueName(base string, used map[string]bool) string {
+	if token.IsKeyword(base) || types.Universe.Lookup(base) != nil {
+		base += "Val"
+	}
+
+	name := base
+	for i := 2; used[name]; i++ {
+		name = base + strconv.Itoa(i)
+	}
+
+	return name
+}
+
+func lowerFirst(name string) string {
+	r, size := utf8.DecodeRuneInString(name)
+
+	return string(unicode.ToLower(r)) + name[size:]
+}
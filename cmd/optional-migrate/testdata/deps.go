@@ -0,0 +1,5 @@
+// Package migrate keeps optional in requirements of the test module, it is
+// used by migrated code.
+package migrate
+
+import _ "github.com/kazhuravlev/optional"
@@ -0,0 +1,15 @@
+module example.com/migrate
+
+go 1.24.0
+
+require (
+	github.com/kazhuravlev/optional v0.0.0-00010101000000-000000000000
+	gopkg.in/guregu/null.v4 v4.0.0
+)
+
+require gopkg.in/yaml.v3 v3.0.1 // indirect
+
+replace (
+	github.com/kazhuravlev/optional => ../../..
+	gopkg.in/guregu/null.v4 => ./null
+)
@@ -0,0 +1,39 @@
+package guregu
+
+import (
+	"time"
+
+	"gopkg.in/guregu/null.v4"
+)
+
+type Profile struct {
+	Bio       null.String
+	Followers null.Int
+	SeenAt    null.Time
+}
+
+func New(bio string, seen *time.Time) Profile {
+	return Profile{
+		Bio:       null.StringFrom(bio),
+		Followers: null.IntFromPtr(nil),
+		SeenAt:    null.TimeFromPtr(seen),
+	}
+}
+
+func (p *Profile) Describe() (string, *int64) {
+	if p.Bio.IsZero() {
+		p.Bio.SetValid("empty")
+	}
+
+	if p.Followers.Valid {
+		return p.Bio.ValueOrZero(), p.Followers.Ptr()
+	}
+
+	return p.Bio.String, nil
+}
+
+func (p *Profile) Merge(other null.String) bool {
+	p.Bio = other
+
+	return p.Bio.Equal(other)
+}
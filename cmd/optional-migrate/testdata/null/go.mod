@@ -0,0 +1,3 @@
+module gopkg.in/guregu/null.v4
+
+go 1.24.0
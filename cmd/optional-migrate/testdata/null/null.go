@@ -0,0 +1,77 @@
+// Package null is a stub of gopkg.in/guregu/null.v4 for tests.
+package null
+
+import (
+	"database/sql"
+	"time"
+)
+
+type String struct{ sql.NullString }
+
+func NewString(s string, valid bool) String {
+	return String{NullString: sql.NullString{String: s, Valid: valid}}
+}
+
+func StringFrom(s string) String { return NewString(s, true) }
+
+func StringFromPtr(s *string) String {
+	if s == nil {
+		return NewString("", false)
+	}
+
+	return NewString(*s, true)
+}
+
+func (s String) ValueOrZero() string { return s.String }
+
+func (s String) Ptr() *string {
+	if !s.Valid {
+		return nil
+	}
+
+	return &s.String
+}
+
+func (s *String) SetValid(v string) { s.String, s.Valid = v, true }
+
+func (s String) IsZero() bool { return !s.Valid }
+
+func (s String) Equal(other String) bool { return s == other }
+
+type Int struct{ sql.NullInt64 }
+
+func IntFrom(i int64) Int { return Int{NullInt64: sql.NullInt64{Int64: i, Valid: true}} }
+
+func IntFromPtr(i *int64) Int {
+	if i == nil {
+		return Int{}
+	}
+
+	return IntFrom(*i)
+}
+
+func (i Int) Ptr() *int64 {
+	if !i.Valid {
+		return nil
+	}
+
+	return &i.Int64
+}
+
+type Time struct{ sql.NullTime }
+
+func TimeFromPtr(t *time.Time) Time {
+	if t == nil {
+		return Time{}
+	}
+
+	return Time{NullTime: sql.NullTime{Time: *t, Valid: true}}
+}
+
+func (t Time) Ptr() *time.Time {
+	if !t.Valid {
+		return nil
+	}
+
+	return &t.Time
+}
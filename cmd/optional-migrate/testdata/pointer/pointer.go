@@ -0,0 +1,78 @@
+package pointer
+
+import (
+	"fmt"
+	"strings"
+)
+
+type Address struct {
+	City string
+}
+
+func (a Address) String() string { return a.City }
+
+func (a *Address) Normalize() { a.City = strings.TrimSpace(a.City) }
+
+type User struct {
+	ID       int64
+	Nickname *string
+	Age      *int
+	Address  *Address
+	Manager  *User
+}
+
+func NewUser(id int64, nickname string) User {
+	return User{
+		ID:       id,
+		Nickname: &nickname,
+		Age:      nil,
+	}
+}
+
+func (u *User) Describe() string {
+	if u.Nickname != nil {
+		fmt.Println("nickname", *u.Nickname)
+	}
+
+	if u.Age == nil {
+		return "unknown"
+	}
+
+	if u.Address != nil && u.Address.City != "" {
+		return fmt.Sprintf("%s from %s, %d", *u.Nickname, u.Address.String(), *u.Age)
+	}
+
+	return fmt.Sprintf("%d", *u.Age)
+}
+
+func (u *User) Update(nickname *string, age int) {
+	u.Nickname = nickname
+	u.Age = &age
+	*u.Age = age + 1
+	u.Address = nil
+}
+
+func (u *User) Fix() {
+	if u.Address != nil {
+		u.Address.Normalize()
+		u.Address.City = "x"
+	}
+
+	name := u.Nickname
+	fmt.Println(name, u.Manager)
+}
+
+func Copy(dst, src *User) {
+	dst.Age = src.Age
+	dst.Nickname = src.Nickname
+}
+
+func Share(dst, src *User) {
+	if src.Age == nil {
+		return
+	}
+
+	dst.Age = src.Age
+	*src.Age = 10
+	fmt.Println(*dst.Age, *src.Age)
+}
@@ -0,0 +1,10 @@
+package pointer
+
+import "testing"
+
+func TestNewUser(t *testing.T) {
+	u := NewUser(1, "kaz")
+	if *u.Nickname != "kaz" {
+		t.Fatal(*u.Nickname)
+	}
+}
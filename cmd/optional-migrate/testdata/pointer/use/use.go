@@ -0,0 +1,12 @@
+package use
+
+import "example.com/migrate/pointer"
+
+func Age(u pointer.User) int {
+	if u.Age != nil {
+		age := 10
+		return *u.Age + age
+	}
+
+	return 0
+}
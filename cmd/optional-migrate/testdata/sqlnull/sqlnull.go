@@ -0,0 +1,44 @@
+package sqlnull
+
+import (
+	"database/sql"
+)
+
+type Account struct {
+	ID        int64
+	Email     sql.NullString
+	DeletedAt sql.NullTime
+	Score     sql.Null[float64]
+	Raw       sql.NullString
+}
+
+func Load(rows *sql.Rows) (Account, error) {
+	var acc Account
+	err := rows.Scan(&acc.ID, &acc.Email, &acc.DeletedAt, &acc.Score)
+
+	return acc, err
+}
+
+func (a *Account) Describe() string {
+	if a.Email.Valid {
+		return a.Email.String
+	}
+
+	if !a.DeletedAt.Valid {
+		return "active"
+	}
+
+	return a.DeletedAt.Time.String()
+}
+
+func (a *Account) Update(email string, score sql.Null[float64]) {
+	a.Email = sql.NullString{String: email, Valid: true}
+	a.DeletedAt = sql.NullTime{}
+	a.Score = score
+	a.Email.String = email
+	a.DeletedAt.Valid = false
+}
+
+func Export(a Account) (sql.NullString, sql.Null[float64]) {
+	return a.Email, a.Score
+}
@@ -0,0 +1,340 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go/types"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/kazhuravlev/optional/openapi"
+	"github.com/kazhuravlev/optional/schema"
+	"golang.org/x/tools/go/packages"
+	"gopkg.in/yaml.v3"
+)
+
+const optionalPath = "github.com/kazhuravlev/optional"
+
+var errPackageInvalid = errors.New("invalid package")
+
+// Components returns OpenAPI document in YAML format with schemas of exported
+// structs of packages. Schemas are the same as openapi.Components returns for
+// these types.
+func Components(tags []string, patterns ...string) ([]byte, error) {
+	var buildFlags []string
+	if len(tags) != 0 {
+		buildFlags = append(buildFlags, "-tags="+strings.Join(tags, ","))
+	}
+
+	// Dependencies are type checked from source, because export data of newer
+	// toolchains may be unsupported.
+	pkgs, err := packages.Load(&packages.Config{ //nolint:exhaustruct
+		Mode: packages.NeedName | packages.NeedFiles | packages.NeedSyntax | packages.NeedTypes |
+			packages.NeedImports | packages.NeedDeps,
+		BuildFlags: buildFlags,
+	}, patterns...)
+	if err != nil {
+		return nil, fmt.Errorf("load packages: %w", err)
+	}
+
+	if len(pkgs) == 0 {
+		return nil, fmt.Errorf("%w: no packages match %s", errPackageInvalid, strings.Join(patterns, " "))
+	}
+
+	slices.SortFunc(pkgs, func(a, b *packages.Package) int { return strings.Compare(a.PkgPath, b.PkgPath) })
+
+	gen := &generator{defs: make(map[string]*schema.Schema), names: make(map[string]string)}
+
+	for _, pkg := range pkgs {
+		if len(pkg.Errors) != 0 {
+			return nil, fmt.Errorf("%w: %s: %s", errPackageInvalid, pkg.PkgPath, pkg.Errors[0].Error())
+		}
+
+		scope := pkg.Types.Scope()
+		for _, name := range scope.Names() {
+			obj, ok := scope.Lookup(name).(*types.TypeName)
+			if !ok || !obj.Exported() || obj.IsAlias() {
+				continue
+			}
+
+			named, ok := obj.Type().(*types.Named)
+			if !ok || named.TypeParams().Len() != 0 {
+				continue
+			}
+
+			if _, ok := named.Underlying().(*types.Struct); ok {
+				gen.schema(named)
+			}
+		}
+	}
+
+	doc := map[string]any{"components": map[string]any{"schemas": gen.defs}}
+
+	buf, err := json.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("encode schemas: %w", err)
+	}
+
+	// JSON is YAML, the node keeps order of keys.
+	var node yaml.Node
+	if err := yaml.Unmarshal(buf, &node); err != nil {
+		return nil, fmt.Errorf("encode schemas: %w", err)
+	}
+
+	setStyle(&node)
+
+	res, err := yaml.Marshal(&node)
+	if err != nil {
+		return nil, fmt.Errorf("encode schemas: %w", err)
+	}
+
+	return res, nil
+}
+
+// setStyle resets the flow style of nodes parsed from JSON.
+func setStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		setStyle(child)
+	}
+}
+
+// generator describes go types the same way as schema.Generator does, but
+// works with type information of packages instead of reflection.
+type generator struct {
+	defs map[string]*schema.Schema
+	// names maps go types to names of their definitions.
+	names map[string]string
+}
+
+//nolint:cyclop
+func (g *generator) schema(typ types.Type) *schema.Schema {
+	if elem, ok := optionalElem(typ, "Val"); ok {
+		return schema.Nullable(g.schema(elem))
+	}
+
+	if elem, ok := optionalElem(typ, "Quoted"); ok {
+		// Quoted is encoded as a string, but unquoted values are accepted too.
+		res := &schema.Schema{Type: schema.Types{"string"}}
+		if elemType := g.schema(elem).Type; len(elemType) == 1 && elemType[0] != "string" {
+			res.Type = append(res.Type, elemType[0])
+		}
+
+		res.Type = append(res.Type, "null")
+
+		return res
+	}
+
+	switch {
+	case isNamed(typ, "time", "Time"):
+		return &schema.Schema{Type: schema.Types{"string"}, Format: "date-time"}
+	case isNamed(typ, "encoding/json", "RawMessage"):
+		return &schema.Schema{}
+	case isMarshaler(typ):
+		return &schema.Schema{Type: schema.Types{"string"}}
+	}
+
+	switch under := typ.Underlying().(type) {
+	case *types.Basic:
+		switch info := under.Info(); {
+		case info&types.IsBoolean != 0:
+			return &schema.Schema{Type: schema.Types{"boolean"}}
+		case info&types.IsInteger != 0:
+			return &schema.Schema{Type: schema.Types{"integer"}}
+		case info&types.IsFloat != 0:
+			return &schema.Schema{Type: schema.Types{"number"}}
+		case info&types.IsString != 0:
+			return &schema.Schema{Type: schema.Types{"string"}}
+		}
+	case *types.Pointer:
+		return g.schema(under.Elem())
+	case *types.Slice:
+		if isByte(under.Elem()) {
+			return &schema.Schema{Type: schema.Types{"string"}, ContentEncoding: "base64"}
+		}
+
+		return &schema.Schema{Type: schema.Types{"array"}, Items: g.schema(under.Elem())}
+	case *types.Array:
+		return &schema.Schema{Type: schema.Types{"array"}, Items: g.schema(under.Elem())}
+	case *types.Map:
+		return &schema.Schema{Type: schema.Types{"object"}, AdditionalProperties: g.schema(under.Elem())}
+	case *types.Struct:
+		named, ok := typ.(*types.Named)
+		if !ok {
+			return g.object(under)
+		}
+
+		key := types.TypeString(named, nil)
+
+		name, ok := g.names[key]
+		if !ok {
+			name = g.defName(named)
+			g.names[key] = name
+			g.defs[name] = &schema.Schema{} // placeholder for recursive types
+			g.defs[name] = g.object(under)
+		}
+
+		return &schema.Schema{Ref: openapi.RefPrefix + name}
+	}
+
+	// Interfaces and other types may contain any value.
+	return &schema.Schema{}
+}
+
+func (g *generator) object(typ *types.Struct) *schema.Schema {
+	res := &schema.Schema{Type: schema.Types{"object"}, Properties: map[string]*schema.Schema{}}
+	g.fields(typ, res)
+
+	return res
+}
+
+func (g *generator) fields(typ *types.Struct, res *schema.Schema) {
+	for i := range typ.NumFields() {
+		field := typ.Field(i)
+
+		tag := reflect.StructTag(typ.Tag(i)).Get("json")
+		if tag == "-" {
+			continue
+		}
+
+		name, opts, _ := strings.Cut(tag, ",")
+		if field.Embedded() && name == "" {
+			embedded := field.Type()
+			if ptr, ok := embedded.(*types.Pointer); ok {
+				embedded = ptr.Elem()
+			}
+
+			if st, ok := embedded.Underlying().(*types.Struct); ok {
+				g.fields(st, res)
+
+				continue
+			}
+		}
+
+		if !field.Exported() {
+			continue
+		}
+
+		if name == "" {
+			name = field.Name()
+		}
+
+		res.Properties[name] = g.schema(field.Type())
+
+		_, isOptional := optionalElem(field.Type(), "Val")
+		if _, ok := optionalElem(field.Type(), "Quoted"); ok {
+			isOptional = true
+		}
+
+		if !isOptional && !hasOption(opts, "omitempty") && !hasOption(opts, "omitzero") {
+			res.Required = append(res.Required, name)
+		}
+	}
+}
+
+// defName returns the name of definition which reflect.Type.Name of the type
+// gives to schema.Generator.
+func (g *generator) defName(named *types.Named) string {
+	obj := named.Obj()
+
+	name := obj.Name()
+	if args := named.TypeArgs(); args.Len() != 0 {
+		list := make([]string, 0, args.Len())
+		for i := range args.Len() {
+			list = append(list, types.TypeString(args.At(i), nil))
+		}
+
+		name += "[" + strings.Join(list, ",") + "]"
+	}
+
+	name = sanitize(name)
+	if _, ok := g.defs[name]; !ok {
+		return name
+	}
+
+	// The same name in different packages.
+	pkg := obj.Pkg().Path()
+	name = sanitize(pkg[strings.LastIndex(pkg, "/")+1:]) + "." + name
+	for i, base := 2, name; ; i++ {
+		if _, ok := g.defs[name]; !ok {
+			return name
+		}
+
+		name = base + strconv.Itoa(i)
+	}
+}
+
+// optionalElem returns type of inner value when typ is optional type name.
+func optionalElem(typ types.Type, name string) (types.Type, bool) {
+	named, ok := typ.(*types.Named)
+	if !ok || !isNamed(named.Origin(), optionalPath, name) || named.TypeArgs().Len() != 1 {
+		return nil, false
+	}
+
+	return named.TypeArgs().At(0), true
+}
+
+func isNamed(typ types.Type, pkgPath, name string) bool {
+	named, ok := typ.(*types.Named)
+	if !ok {
+		return false
+	}
+
+	obj := named.Obj()
+
+	return obj.Pkg() != nil && obj.Pkg().Path() == pkgPath && obj.Name() == name
+}
+
+// isMarshaler reports whether typ or *typ has MarshalJSON or MarshalText
+// method.
+func isMarshaler(typ types.Type) bool {
+	if _, ok := typ.(*types.Pointer); !ok && !types.IsInterface(typ) {
+		typ = types.NewPointer(typ)
+	}
+
+	methods := types.NewMethodSet(typ)
+	for _, name := range []string{"MarshalJSON", "MarshalText"} {
+		sel := methods.Lookup(nil, name)
+		if sel == nil {
+			continue
+		}
+
+		if sig, ok := sel.Type().(*types.Signature); ok && sig.Params().Len() == 0 && sig.Results().Len() == 2 {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isByte(typ types.Type) bool {
+	basic, ok := typ.Underlying().(*types.Basic)
+
+	return ok && basic.Kind() == types.Uint8
+}
+
+func sanitize(name string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '_', r == '.':
+			return r
+		default:
+			return '_'
+		}
+	}, name)
+}
+
+func hasOption(opts, option string) bool {
+	for opts != "" {
+		var opt string
+		opt, opts, _ = strings.Cut(opts, ",")
+		if opt == option {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,92 @@
+// Command optional-oapi annotates OpenAPI document for oapi-codegen, so that
+// non-required and nullable scalar properties are generated as optional.Val[T].
+//
+//	optional-oapi -in api.yaml -out api.annotated.yaml
+//
+// With package patterns it writes OpenAPI 3.1 components with schemas of
+// exported structs of these packages instead:
+//
+//	optional-oapi -out components.yaml ./api/...
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/kazhuravlev/optional/openapi"
+)
+
+func main() {
+	in := flag.String("in", "-", "input document in YAML or JSON format, - for stdin")
+	out := flag.String("out", "-", "output document in YAML format, - for stdout")
+	tags := flag.String("tags", "", "comma-separated list of build tags")
+	flag.Parse()
+
+	var tagList []string
+	if *tags != "" {
+		tagList = strings.Split(*tags, ",")
+	}
+
+	if err := run(*in, *out, tagList, flag.Args()); err != nil {
+		fmt.Fprintln(os.Stderr, "optional-oapi:", err)
+		os.Exit(1)
+	}
+}
+
+// run annotates the document in or, when patterns are given, generates
+// components of packages, and writes the result into out.
+func run(in, out string, tags, patterns []string) error {
+	var (
+		res []byte
+		err error
+	)
+
+	if len(patterns) != 0 {
+		res, err = Components(tags, patterns...)
+	} else {
+		res, err = annotate(in)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if out == "-" {
+		_, err = os.Stdout.Write(res)
+	} else {
+		err = os.WriteFile(out, res, 0o644) //nolint:gosec,mnd
+	}
+
+	if err != nil {
+		return fmt.Errorf("write document: %w", err)
+	}
+
+	return nil
+}
+
+func annotate(in string) ([]byte, error) {
+	var (
+		src []byte
+		err error
+	)
+
+	if in == "-" {
+		src, err = io.ReadAll(os.Stdin)
+	} else {
+		src, err = os.ReadFile(in)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("read document: %w", err)
+	}
+
+	res, err := openapi.AnnotateYAML(src)
+	if err != nil {
+		return nil, fmt.Errorf("annotate document: %w", err)
+	}
+
+	return res, nil
+}
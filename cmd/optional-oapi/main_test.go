@@ -0,0 +1,107 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kazhuravlev/optional/cmd/optional-oapi/testdata/api"
+	"github.com/kazhuravlev/optional/openapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+func TestRun(t *testing.T) {
+	t.Parallel()
+
+	table := []struct {
+		name     string
+		in       string
+		patterns []string
+		golden   string
+	}{
+		{
+			name:     "annotate",
+			in:       filepath.Join("testdata", "api.yaml"),
+			patterns: nil,
+			golden:   filepath.Join("testdata", "api.yaml.golden"),
+		},
+		{
+			name:     "components",
+			in:       "-",
+			patterns: []string{"./testdata/api/..."},
+			golden:   filepath.Join("testdata", "components.yaml.golden"),
+		},
+	}
+
+	for _, row := range table {
+		t.Run(row.name, func(t *testing.T) {
+			t.Parallel()
+
+			out := filepath.Join(t.TempDir(), "out.yaml")
+			require.NoError(t, run(row.in, out, nil, row.patterns))
+
+			res, err := os.ReadFile(out)
+			require.NoError(t, err)
+
+			if *update {
+				require.NoError(t, os.WriteFile(row.golden, res, 0o644)) //nolint:gosec
+			}
+
+			expected, err := os.ReadFile(row.golden)
+			require.NoError(t, err)
+			assert.Equal(t, string(expected), string(res))
+		})
+	}
+}
+
+func TestRun_Errors(t *testing.T) {
+	t.Parallel()
+
+	out := filepath.Join(t.TempDir(), "out.yaml")
+
+	err := run("-", out, nil, []string{"./testdata/missing"})
+	require.ErrorIs(t, err, errPackageInvalid)
+
+	err = run("-", out, nil, []string{"example.com/missing/..."})
+	require.ErrorIs(t, err, errPackageInvalid)
+
+	err = run(filepath.Join("testdata", "missing.yaml"), out, nil, nil)
+	require.ErrorIs(t, err, os.ErrNotExist)
+
+	assert.NoFileExists(t, out)
+}
+
+// Schemas of packages are the same as schemas of reflected types.
+func TestComponents_Reflect(t *testing.T) {
+	t.Parallel()
+
+	res, err := Components(nil, "./testdata/api/...")
+	require.NoError(t, err)
+
+	var doc struct {
+		Components struct {
+			Schemas map[string]any `yaml:"schemas"`
+		} `yaml:"components"`
+	}
+	require.NoError(t, yaml.Unmarshal(res, &doc))
+
+	buf, err := json.Marshal(openapi.Components(api.Address{}, api.Contact{}, api.Owner{}, api.Pet{}, api.Token{}))
+	require.NoError(t, err)
+
+	var expected map[string]any
+	require.NoError(t, json.Unmarshal(buf, &expected))
+
+	buf, err = json.Marshal(doc.Components.Schemas)
+	require.NoError(t, err)
+
+	var actual map[string]any
+	require.NoError(t, json.Unmarshal(buf, &actual))
+
+	assert.Equal(t, expected, actual)
+}
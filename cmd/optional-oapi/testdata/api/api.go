@@ -0,0 +1,72 @@
+// Package api contains types which are described as OpenAPI components.
+package api
+
+import (
+	"encoding/json"
+	"net/netip"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kazhuravlev/optional"
+	"github.com/kazhuravlev/optional/cmd/optional-oapi/testdata/api/geo"
+)
+
+type Pet struct {
+	ID       int64                    `json:"id"`
+	Name     string                   `json:"name"`
+	Tag      optional.Val[string]     `json:"tag"`
+	Weight   optional.Quoted[float64] `json:"weight"`
+	Born     optional.Val[time.Time]  `json:"born"`
+	Owner    optional.Val[Owner]      `json:"owner"`
+	Location struct {
+		Lat float64 `json:"lat"`
+		Lon float64 `json:"lon"`
+	} `json:"location"`
+	Photos  [][]byte                     `json:"photos,omitempty"`
+	Labels  map[string]optional.Val[int] `json:"labels,omitempty"`
+	Parent  *Pet                         `json:"parent,omitempty"`
+	Level   optional.Quoted[Level]       `json:"level"`
+	Address optional.Val[netip.Addr]     `json:"address"`
+	Token   *Token                       `json:"token,omitempty"`
+	private string
+}
+
+type Owner struct {
+	Contact
+
+	Email   string                    `json:"email"`
+	Address optional.Val[geo.Address] `json:"address"`
+	Ignored string                    `json:"-"`
+}
+
+type Contact struct {
+	Phone optional.Val[string] `json:"phone"`
+}
+
+// Address has the same name as geo.Address.
+type Address struct {
+	Line string `json:"line"`
+}
+
+type notExported struct {
+	Name string `json:"name"`
+}
+
+type Kind string
+
+// Level is encoded as a string by its own marshaler.
+type Level int
+
+func (l Level) MarshalJSON() ([]byte, error) {
+	return json.Marshal("level-" + strconv.Itoa(int(l))) //nolint:wrapcheck
+}
+
+// Token is encoded as a string by its text marshaler with pointer receiver.
+type Token struct {
+	Parts []string
+}
+
+func (t *Token) MarshalText() ([]byte, error) {
+	return []byte(strings.Join(t.Parts, ".")), nil
+}
@@ -0,0 +1,10 @@
+// Package geo contains a type which is referenced by package api.
+package geo
+
+import "github.com/kazhuravlev/optional"
+
+type Address struct {
+	City    string                 `json:"city"`
+	Zip     optional.Quoted[int32] `json:"zip"`
+	Country string                 `json:"country,omitempty"`
+}
@@ -0,0 +1,18 @@
+// Command optional-vet reports unsafe usage of optional.Val. It can be run
+// directly or as a vet tool:
+//
+//	go install github.com/kazhuravlev/optional/cmd/optional-vet@latest
+//	optional-vet ./...
+//	go vet -vettool=$(which optional-vet) ./...
+//
+// Use -fix to apply suggested fixes.
+package main
+
+import (
+	"github.com/kazhuravlev/optional/analyzer"
+	"golang.org/x/tools/go/analysis/singlechecker"
+)
+
+func main() {
+	singlechecker.Main(analyzer.Analyzer)
+}
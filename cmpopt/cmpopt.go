@@ -0,0 +1,52 @@
+// Package cmpopt contains options to compare optional values with github.com/google/go-cmp.
+//
+//	if diff := cmp.Diff(want, got, cmpopt.Val()); diff != "" {
+//		t.Errorf("user mismatch (-want +got):\n%s", diff)
+//	}
+//
+// Without this option cmp panics on optional.Val because of unexported fields.
+package cmpopt
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/kazhuravlev/optional"
+)
+
+// Empty is how an empty optional value is shown in diffs.
+type Empty struct{}
+
+// Some is how a present optional value is shown in diffs.
+type Some struct {
+	Value any
+}
+
+var pkgPath = reflect.TypeFor[optional.Val[int]]().PkgPath()
+
+// Val returns an option which compares optional.Val and optional.Quoted by
+// presence and value. The value of empty optionals is ignored. Other options
+// passed to cmp apply to values inside optionals too.
+func Val() cmp.Option { //nolint:ireturn
+	return cmp.FilterPath(func(path cmp.Path) bool {
+		return isOptional(path.Last().Type())
+	}, cmp.Transformer("optional", transform))
+}
+
+func transform(val any) any {
+	rv := reflect.ValueOf(val)
+	if !rv.MethodByName("HasVal").Call(nil)[0].Bool() {
+		return Empty{}
+	}
+
+	return Some{Value: rv.MethodByName("Val").Call(nil)[0].Interface()}
+}
+
+func isOptional(typ reflect.Type) bool {
+	if typ == nil || typ.PkgPath() != pkgPath {
+		return false
+	}
+
+	return strings.HasPrefix(typ.Name(), "Val[") || strings.HasPrefix(typ.Name(), "Quoted[")
+}
@@ -0,0 +1,98 @@
+package cmpopt_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/kazhuravlev/optional"
+	"github.com/kazhuravlev/optional/cmpopt"
+	"github.com/stretchr/testify/assert"
+)
+
+type user struct {
+	ID       int
+	Nickname optional.Val[string]
+	Age      *optional.Val[int]
+	Tags     optional.Val[[]string]
+	Score    optional.Quoted[float64]
+	Parent   optional.Val[optional.Val[int]]
+}
+
+func TestVal_Equal(t *testing.T) {
+	t.Parallel()
+
+	age := optional.New(30)
+	age2 := optional.New(30)
+
+	// Empty values which differ only in the hidden value.
+	var dirty optional.Val[string]
+	dirty.Set("hidden")
+	dirty.Reset()
+
+	table := []struct {
+		name string
+		a, b user
+		exp  bool
+	}{
+		{name: "zero", a: user{}, b: user{}, exp: true},
+		{
+			name: "equal",
+			a:    user{ID: 1, Nickname: optional.New("kaz"), Age: &age, Tags: optional.New([]string{"a"}), Score: optional.NewQuoted(1.5)},
+			b:    user{ID: 1, Nickname: optional.New("kaz"), Age: &age2, Tags: optional.New([]string{"a"}), Score: optional.NewQuoted(1.5)},
+			exp:  true,
+		},
+		{name: "hidden_value", a: user{Nickname: dirty}, b: user{Nickname: optional.Empty[string]()}, exp: true},
+		{name: "presence", a: user{Nickname: optional.New("")}, b: user{Nickname: optional.Empty[string]()}, exp: false},
+		{name: "value", a: user{Nickname: optional.New("a")}, b: user{Nickname: optional.New("b")}, exp: false},
+		{name: "pointer", a: user{Age: &age}, b: user{Age: nil}, exp: false},
+		{name: "quoted", a: user{Score: optional.NewQuoted(1.5)}, b: user{Score: optional.EmptyQuoted[float64]()}, exp: false},
+		{name: "nested", a: user{Parent: optional.New(optional.New(1))}, b: user{Parent: optional.New(optional.Empty[int]())}, exp: false},
+		{name: "nested_equal", a: user{Parent: optional.New(optional.Empty[int]())}, b: user{Parent: optional.New(optional.Empty[int]())}, exp: true},
+	}
+
+	for _, row := range table {
+		t.Run(row.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, row.exp, cmp.Equal(row.a, row.b, cmpopt.Val()))
+		})
+	}
+}
+
+func TestVal_OtherOptions(t *testing.T) {
+	t.Parallel()
+
+	a := optional.New([]string{})
+	b := optional.New([]string(nil))
+
+	assert.False(t, cmp.Equal(a, b, cmpopt.Val()))
+	assert.True(t, cmp.Equal(a, b, cmpopt.Val(), cmpopts.EquateEmpty()))
+
+	now := time.Now()
+	assert.True(t, cmp.Equal(optional.New(now), optional.New(now.Add(time.Millisecond)),
+		cmpopt.Val(), cmpopts.EquateApproxTime(time.Second)))
+}
+
+func TestVal_Diff(t *testing.T) {
+	t.Parallel()
+
+	diff := cmp.Diff(
+		user{ID: 1, Nickname: optional.New("kaz")},
+		user{ID: 1, Nickname: optional.Empty[string]()},
+		cmpopt.Val(),
+	)
+
+	// Output of cmp is unstable on purpose, so only fragments are checked.
+	assert.Contains(t, diff, `Nickname: optional.Val[string](Inverse(optional, any(cmpopt.Some{Value: string("kaz")}))),`)
+	assert.Contains(t, diff, `Nickname: optional.Val[string](Inverse(optional, any(cmpopt.Empty{}))),`)
+	assert.NotContains(t, diff, "hasVal")
+}
+
+func TestVal_Panics(t *testing.T) {
+	t.Parallel()
+
+	assert.Panics(t, func() { cmp.Equal(optional.New(1), optional.New(1)) })
+	assert.NotPanics(t, func() { cmp.Equal(optional.New(1), optional.New(1), cmpopt.Val()) })
+}
@@ -0,0 +1,269 @@
+package optional
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DecodeJSON is json.Unmarshal which fills Path of DecodeError with the
+// dotted path of the failed field, like "users.0.address.zip". encoding/json
+// does not tell which field an error of UnmarshalJSON belongs to, so the
+// document is walked again to find it when decoding fails.
+func DecodeJSON(data []byte, dst any) error {
+	err := json.Unmarshal(data, dst)
+	if !errors.Is(err, ErrDecode) {
+		return err //nolint:wrapcheck
+	}
+
+	if path, ok := findJSONError(data, reflect.TypeOf(dst)); ok {
+		return WithPath(err, path)
+	}
+
+	return err //nolint:wrapcheck
+}
+
+// DecodeYAML is yaml.Unmarshal which fills Path of DecodeError with the
+// dotted path of the failed field, the same way as DecodeJSON does.
+func DecodeYAML(data []byte, dst any) error {
+	err := yaml.Unmarshal(data, dst)
+	if !errors.Is(err, ErrDecode) {
+		return err //nolint:wrapcheck
+	}
+
+	var doc yaml.Node
+	if yaml.Unmarshal(data, &doc) != nil || len(doc.Content) == 0 {
+		return err //nolint:wrapcheck
+	}
+
+	if path, ok := findYAMLError(doc.Content[0], reflect.TypeOf(dst)); ok {
+		return WithPath(err, path)
+	}
+
+	return err //nolint:wrapcheck
+}
+
+// jsonMember is a member of JSON object in order of the document.
+type jsonMember struct {
+	key   string
+	value json.RawMessage
+}
+
+// findJSONError returns the path of the first value in data which fails to
+// decode into a json.Unmarshaler with DecodeError. Decoders stop on such
+// errors, so the first one in order of the document is the reported one.
+func findJSONError(data []byte, typ reflect.Type) (string, bool) {
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+
+	if reflect.PointerTo(typ).Implements(reflect.TypeFor[json.Unmarshaler]()) {
+		//nolint:forcetypeassert
+		err := reflect.New(typ).Interface().(json.Unmarshaler).UnmarshalJSON(data)
+
+		return "", errors.Is(err, ErrDecode)
+	}
+
+	switch typ.Kind() { //nolint:exhaustive
+	case reflect.Slice, reflect.Array:
+		var items []json.RawMessage
+		if json.Unmarshal(data, &items) != nil {
+			return "", false
+		}
+
+		for i, item := range items {
+			if path, ok := findJSONError(item, typ.Elem()); ok {
+				return joinPath(strconv.Itoa(i), path), true
+			}
+		}
+	case reflect.Map:
+		members, err := jsonObject(data)
+		if err != nil {
+			return "", false
+		}
+
+		for _, member := range members {
+			if path, ok := findJSONError(member.value, typ.Elem()); ok {
+				return joinPath(member.key, path), true
+			}
+		}
+	case reflect.Struct:
+		members, err := jsonObject(data)
+		if err != nil {
+			return "", false
+		}
+
+		fields := jsonFields(typ)
+		for _, member := range members {
+			name, field, ok := lookupJSONField(fields, member.key)
+			if !ok {
+				continue
+			}
+
+			if path, ok := findJSONError(member.value, field); ok {
+				return joinPath(name, path), true
+			}
+		}
+	}
+
+	return "", false
+}
+
+// jsonObject returns members of JSON object in order of the document.
+func jsonObject(data []byte) ([]jsonMember, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		return nil, errors.New("not an object") //nolint:goerr113
+	}
+
+	var members []jsonMember
+
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err //nolint:wrapcheck
+		}
+
+		key, _ := tok.(string)
+
+		var value json.RawMessage
+		if err := dec.Decode(&value); err != nil {
+			return nil, err //nolint:wrapcheck
+		}
+
+		members = append(members, jsonMember{key: key, value: value})
+	}
+
+	return members, nil
+}
+
+// jsonFields returns types of struct fields by their JSON names. Fields of
+// embedded structs are promoted the same way as encoding/json does.
+func jsonFields(typ reflect.Type) map[string]reflect.Type {
+	fields := make(map[string]reflect.Type, typ.NumField())
+
+	for i := range typ.NumField() {
+		field := typ.Field(i)
+
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+
+		name, _, _ := strings.Cut(tag, ",")
+
+		inner := field.Type
+		if inner.Kind() == reflect.Pointer {
+			inner = inner.Elem()
+		}
+
+		if field.Anonymous && name == "" && inner.Kind() == reflect.Struct {
+			for innerName, innerType := range jsonFields(inner) {
+				if _, ok := fields[innerName]; !ok {
+					fields[innerName] = innerType
+				}
+			}
+
+			continue
+		}
+
+		if !field.IsExported() {
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+
+		fields[name] = field.Type
+	}
+
+	return fields
+}
+
+// lookupJSONField finds field by key, preferring an exact match over
+// a case-insensitive one like encoding/json does.
+func lookupJSONField(fields map[string]reflect.Type, key string) (string, reflect.Type, bool) {
+	if typ, ok := fields[key]; ok {
+		return key, typ, true
+	}
+
+	for name, typ := range fields {
+		if strings.EqualFold(name, key) {
+			return name, typ, true
+		}
+	}
+
+	return "", nil, false
+}
+
+// findYAMLError is findJSONError for YAML nodes.
+func findYAMLError(node *yaml.Node, typ reflect.Type) (string, bool) {
+	for node.Kind == yaml.AliasNode && node.Alias != nil {
+		node = node.Alias
+	}
+
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+
+	if reflect.PointerTo(typ).Implements(reflect.TypeFor[yaml.Unmarshaler]()) {
+		//nolint:forcetypeassert
+		err := reflect.New(typ).Interface().(yaml.Unmarshaler).UnmarshalYAML(node)
+
+		return "", errors.Is(err, ErrDecode)
+	}
+
+	switch typ.Kind() { //nolint:exhaustive
+	case reflect.Slice, reflect.Array:
+		if node.Kind != yaml.SequenceNode {
+			return "", false
+		}
+
+		for i, item := range node.Content {
+			if path, ok := findYAMLError(item, typ.Elem()); ok {
+				return joinPath(strconv.Itoa(i), path), true
+			}
+		}
+	case reflect.Map:
+		if node.Kind != yaml.MappingNode {
+			return "", false
+		}
+
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key := node.Content[i].Value
+			if path, ok := findYAMLError(node.Content[i+1], typ.Elem()); ok {
+				return joinPath(key, path), true
+			}
+		}
+	case reflect.Struct:
+		if node.Kind != yaml.MappingNode {
+			return "", false
+		}
+
+		fields, inlineMap := yamlFields(typ)
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key := node.Content[i].Value
+
+			field, ok := fields[key]
+			switch {
+			case ok:
+			case inlineMap != nil:
+				field = inlineMap.Elem()
+			default:
+				continue
+			}
+
+			if path, ok := findYAMLError(node.Content[i+1], field); ok {
+				return joinPath(key, path), true
+			}
+		}
+	}
+
+	return "", false
+}
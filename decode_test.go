@@ -0,0 +1,125 @@
+package optional
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type decodeAddress struct {
+	City Val[string] `json:"city" yaml:"city"`
+	Zip  Val[int]    `json:"zip"  yaml:"zip"`
+}
+
+type decodeInner struct {
+	Name string `json:"name" yaml:"name"`
+}
+
+type decodeUser struct {
+	Name      Val[string]              `json:"name"      yaml:"name"`
+	Address   decodeAddress            `json:"address"   yaml:"address"`
+	Addresses []decodeAddress          `json:"addresses" yaml:"addresses"`
+	Labels    map[string]Val[int]      `json:"labels"    yaml:"labels"`
+	Inner     Val[decodeInner]         `json:"inner"     yaml:"inner"`
+	Ptr       *decodeAddress           `json:"ptr"       yaml:"ptr"`
+	Other     string                   `json:"other"     yaml:"other"`
+	Nested    Val[map[string]Val[int]] `json:"nested"    yaml:"nested"`
+}
+
+func TestDecodeJSON(t *testing.T) {
+	t.Parallel()
+
+	table := []struct {
+		name  string
+		input string
+		path  string
+	}{
+		{name: "field", input: `{"name":1}`, path: "name"},
+		{name: "nested", input: `{"other":"x","address":{"city":"c","zip":"x"}}`, path: "address.zip"},
+		{name: "case_insensitive", input: `{"Address":{"ZIP":"x"}}`, path: "address.zip"},
+		{name: "slice", input: `{"addresses":[{"zip":1},{"zip":"x"}]}`, path: "addresses.1.zip"},
+		{name: "map", input: `{"labels":{"a":1,"b":"x"}}`, path: "labels.b"},
+		{name: "pointer", input: `{"ptr":{"city":1}}`, path: "ptr.city"},
+		{name: "inside_value", input: `{"inner":{"name":1}}`, path: "inner.name"},
+		{name: "first_in_document", input: `{"address":{"zip":"x"},"name":1}`, path: "address.zip"},
+	}
+
+	for _, row := range table {
+		t.Run(row.name, func(t *testing.T) {
+			t.Parallel()
+
+			var user decodeUser
+			err := DecodeJSON([]byte(row.input), &user)
+			require.Error(t, err)
+
+			var decodeErr *DecodeError
+			require.ErrorAs(t, err, &decodeErr)
+			assert.Equal(t, row.path, decodeErr.Path)
+			assert.Contains(t, err.Error(), "field "+row.path+": ")
+		})
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		t.Parallel()
+
+		var user decodeUser
+		require.NoError(t, DecodeJSON([]byte(`{"name":"n","address":{"zip":1}}`), &user))
+		assert.Equal(t, New("n"), user.Name)
+		assert.Equal(t, New(1), user.Address.Zip)
+	})
+
+	t.Run("other_errors", func(t *testing.T) {
+		t.Parallel()
+
+		var user decodeUser
+		err := DecodeJSON([]byte(`{"other":1}`), &user)
+		require.Error(t, err)
+		assert.False(t, errors.Is(err, ErrDecode))
+
+		require.Error(t, DecodeJSON([]byte(`{`), &user))
+	})
+}
+
+func TestDecodeYAML(t *testing.T) {
+	t.Parallel()
+
+	table := []struct {
+		name  string
+		input string
+		path  string
+	}{
+		{name: "field", input: "name: [1]", path: "name"},
+		{name: "nested", input: "other: x\naddress: {city: c, zip: x}", path: "address.zip"},
+		{name: "slice", input: "addresses: [{zip: 1}, {zip: x}]", path: "addresses.1.zip"},
+		{name: "map", input: "labels: {a: 1, b: x}", path: "labels.b"},
+		{name: "pointer", input: "ptr: {city: [1]}", path: "ptr.city"},
+		{name: "alias", input: "other: &x y\naddress: {zip: *x}", path: "address.zip"},
+		{name: "first_in_document", input: "address: {zip: x}\nname: [1]", path: "address.zip"},
+	}
+
+	for _, row := range table {
+		t.Run(row.name, func(t *testing.T) {
+			t.Parallel()
+
+			var user decodeUser
+			err := DecodeYAML([]byte(row.input), &user)
+			require.Error(t, err)
+
+			var decodeErr *DecodeError
+			require.ErrorAs(t, err, &decodeErr)
+			assert.Equal(t, row.path, decodeErr.Path)
+			assert.Contains(t, err.Error(), "field "+row.path+": ")
+		})
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		t.Parallel()
+
+		var user decodeUser
+		require.NoError(t, DecodeYAML([]byte("name: n\naddress: {zip: 1}"), &user))
+		assert.Equal(t, New("n"), user.Name)
+		assert.Equal(t, New(1), user.Address.Zip)
+	})
+}
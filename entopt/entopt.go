@@ -0,0 +1,88 @@
+// Package entopt contains helpers to use optional.Val fields in ent schemas.
+//
+//	func (User) Fields() []ent.Field {
+//		return []ent.Field{
+//			entopt.String("nickname"),
+//			field.String("email").
+//				GoType(optional.Val[string]{}).
+//				ValueScanner(entopt.ValueScanner[string]()).
+//				Optional().
+//				Comment("Custom options"),
+//		}
+//	}
+//
+// optional.Val implements field.ValueScanner by itself and converts values
+// the same way as sql.Null does. NULL becomes an empty value.
+package entopt
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"github.com/kazhuravlev/optional"
+)
+
+// ValueScanner returns an external value scanner for optional.Val[T] field.
+func ValueScanner[T any]() field.ValueScannerFunc[optional.Val[T], *sql.Null[T]] {
+	return field.ValueScannerFunc[optional.Val[T], *sql.Null[T]]{
+		V: func(val optional.Val[T]) (driver.Value, error) {
+			return val.Value() //nolint:wrapcheck
+		},
+		S: func(val *sql.Null[T]) (optional.Val[T], error) {
+			return optional.FromSQLNull(*val), nil
+		},
+	}
+}
+
+// String returns a nullable string field of optional.Val[string] type.
+func String(name string) ent.Field {
+	return field.String(name).
+		GoType(optional.Val[string]{}).
+		Optional()
+}
+
+// Int returns a nullable integer field of optional.Val[int] type.
+func Int(name string) ent.Field {
+	return field.Int(name).
+		GoType(optional.Val[int]{}).
+		Optional()
+}
+
+// Int64 returns a nullable integer field of optional.Val[int64] type.
+func Int64(name string) ent.Field {
+	return field.Int64(name).
+		GoType(optional.Val[int64]{}).
+		Optional()
+}
+
+// Float returns a nullable float field of optional.Val[float64] type.
+func Float(name string) ent.Field {
+	return field.Float(name).
+		GoType(optional.Val[float64]{}).
+		Optional()
+}
+
+// Bool returns a nullable boolean field of optional.Val[bool] type.
+func Bool(name string) ent.Field {
+	return field.Bool(name).
+		GoType(optional.Val[bool]{}).
+		Optional()
+}
+
+// Time returns a nullable time field of optional.Val[time.Time] type.
+func Time(name string) ent.Field {
+	return field.Time(name).
+		GoType(optional.Val[time.Time]{}).
+		Optional()
+}
+
+// Bytes returns a nullable bytes field of optional.Val[[]byte] type.
+func Bytes(name string) ent.Field {
+	return field.Bytes(name).
+		GoType(optional.Val[[]byte]{}).
+		ValueScanner(ValueScanner[[]byte]()).
+		Optional()
+}
@@ -0,0 +1,82 @@
+package entopt
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"github.com/kazhuravlev/optional"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFields(t *testing.T) {
+	t.Parallel()
+
+	table := []struct {
+		field ent.Field
+		typ   field.Type
+		ident string
+	}{
+		{field: String("f"), typ: field.TypeString, ident: "optional.Val[string]"},
+		{field: Int("f"), typ: field.TypeInt, ident: "optional.Val[int]"},
+		{field: Int64("f"), typ: field.TypeInt64, ident: "optional.Val[int64]"},
+		{field: Float("f"), typ: field.TypeFloat64, ident: "optional.Val[float64]"},
+		{field: Bool("f"), typ: field.TypeBool, ident: "optional.Val[bool]"},
+		{field: Time("f"), typ: field.TypeTime, ident: "optional.Val[time.Time]"},
+		{field: Bytes("f"), typ: field.TypeBytes, ident: "optional.Val[[]uint8]"},
+	}
+
+	for i := range table {
+		row := table[i]
+		t.Run(row.ident, func(t *testing.T) {
+			t.Parallel()
+
+			desc := row.field.Descriptor()
+			require.NoError(t, desc.Err)
+			assert.True(t, desc.Optional)
+			assert.False(t, desc.Nillable)
+			assert.Equal(t, row.typ, desc.Info.Type)
+			assert.Equal(t, row.ident, desc.Info.Ident)
+		})
+	}
+}
+
+func TestValueScanner(t *testing.T) {
+	t.Parallel()
+
+	desc := field.String("f").
+		GoType(optional.Val[string]{}).
+		ValueScanner(ValueScanner[string]()).
+		Optional().
+		Descriptor()
+	require.NoError(t, desc.Err)
+
+	vs := ValueScanner[string]()
+
+	val, err := vs.Value(optional.New("hi"))
+	require.NoError(t, err)
+	assert.Equal(t, "hi", val)
+
+	val, err = vs.Value(optional.Empty[string]())
+	require.NoError(t, err)
+	assert.Nil(t, val)
+
+	scanned := vs.ScanValue()
+	require.NoError(t, scanned.Scan([]byte("hi")))
+	res, err := vs.FromValue(scanned)
+	require.NoError(t, err)
+	assert.Equal(t, optional.New("hi"), res)
+
+	scanned = vs.ScanValue()
+	require.NoError(t, scanned.Scan(nil))
+	res, err = vs.FromValue(scanned)
+	require.NoError(t, err)
+	assert.Equal(t, optional.Empty[string](), res)
+
+	tvs := ValueScanner[time.Time]()
+	_, err = tvs.FromValue(&sql.NullTime{})
+	require.Error(t, err)
+}
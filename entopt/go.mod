@@ -0,0 +1,16 @@
+module github.com/kazhuravlev/optional/entopt
+
+go 1.22.0
+
+require (
+	entgo.io/ent v0.14.0
+	github.com/kazhuravlev/optional v0.7.0
+	github.com/stretchr/testify v1.11.1
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.2-0.20180830191138-d8f796af33cc // indirect
+	github.com/kr/text v0.2.0 // indirect
+	github.com/pmezard/go-difflib v1.0.1-0.20181226105442-5d4384ee4fb2 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
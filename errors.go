@@ -0,0 +1,146 @@
+package optional
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// Formats of DecodeError.
+const (
+	FormatJSON    = "json"
+	FormatYAML    = "yaml"
+	FormatSQL     = "sql"
+	FormatGraphQL = "graphql"
+	FormatText    = "text"
+)
+
+var (
+	// ErrEmptyInput returned (wrapped into DecodeError) when there is no data to decode.
+	ErrEmptyInput = errors.New("empty input")
+	// ErrTypeMismatch matches any ScanTypeError with errors.Is.
+	ErrTypeMismatch = errors.New("unexpected value type")
+	// ErrDecode matches any DecodeError with errors.Is.
+	ErrDecode = errors.New("decode optional value")
+	// ErrTextUnsupported returned by MarshalText and UnmarshalText when T does
+	// not implement encoding.TextMarshaler or encoding.TextUnmarshaler.
+	ErrTextUnsupported = errors.New("type does not support text encoding")
+)
+
+// ScanTypeError describes a database value which can not be scanned into Val.
+type ScanTypeError struct {
+	// Want is the type of inner value.
+	Want reflect.Type
+	// Got is the type of scanned value.
+	Got reflect.Type
+	// Path is a dotted path of struct field. It is filled by struct-level helpers.
+	Path string
+	// Err is a reason of conversion failure, if any.
+	Err error
+}
+
+func (e *ScanTypeError) Error() string {
+	msg := fmt.Sprintf("unexpected value type: can not scan %s into %s", typeName(e.Got), typeName(e.Want))
+	if e.Err != nil {
+		msg += ": " + e.Err.Error()
+	}
+
+	return withPath(e.Path, msg)
+}
+
+func (e *ScanTypeError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether target is ErrTypeMismatch.
+func (e *ScanTypeError) Is(target error) bool {
+	return target == ErrTypeMismatch //nolint:errorlint,goerr113
+}
+
+// DecodeError describes a failure of decoding an inner value of Val.
+type DecodeError struct {
+	// Format is one of FormatJSON, FormatYAML, FormatSQL, FormatGraphQL, FormatText.
+	Format string
+	// Type is the type of inner value.
+	Type reflect.Type
+	// Path is a dotted path of the failed field. UnmarshalJSON fills the
+	// path inside the value, DecodeJSON, DecodeYAML and other struct-level
+	// helpers prepend the path of the value.
+	Path string
+	// Err is a reason of failure.
+	Err error
+}
+
+func (e *DecodeError) Error() string {
+	return withPath(e.Path, fmt.Sprintf("unmarshal optional value of type %s from %s: %v", typeName(e.Type), e.Format, e.Err))
+}
+
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether target is ErrDecode.
+func (e *DecodeError) Is(target error) bool {
+	return target == ErrDecode //nolint:errorlint,goerr113
+}
+
+// WithPath prepends path to the Path of ScanTypeError or DecodeError from
+// err chain. It is used by struct-level helpers to point to the failed field.
+func WithPath(err error, path string) error {
+	var scanErr *ScanTypeError
+	if errors.As(err, &scanErr) {
+		scanErr.Path = joinPath(path, scanErr.Path)
+	}
+
+	var decodeErr *DecodeError
+	if errors.As(err, &decodeErr) {
+		decodeErr.Path = joinPath(path, decodeErr.Path)
+	}
+
+	return err
+}
+
+func newDecodeError[T any](format string, err error) *DecodeError {
+	return &DecodeError{
+		Format: format,
+		Type:   reflect.TypeFor[T](),
+		Path:   "",
+		Err:    err,
+	}
+}
+
+func newScanTypeError[T any](value any, err error) *ScanTypeError {
+	return &ScanTypeError{
+		Want: reflect.TypeFor[T](),
+		Got:  reflect.TypeOf(value),
+		Path: "",
+		Err:  err,
+	}
+}
+
+func joinPath(prefix, path string) string {
+	switch {
+	case prefix == "":
+		return path
+	case path == "":
+		return prefix
+	default:
+		return prefix + "." + path
+	}
+}
+
+func withPath(path, msg string) string {
+	if path == "" {
+		return msg
+	}
+
+	return "field " + path + ": " + msg
+}
+
+func typeName(t reflect.Type) string {
+	if t == nil {
+		return "nil"
+	}
+
+	return t.String()
+}
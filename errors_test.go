@@ -0,0 +1,124 @@
+package optional
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestDecodeError(t *testing.T) {
+	t.Parallel()
+
+	t.Run("json_empty_input", func(t *testing.T) {
+		t.Parallel()
+
+		var val Val[int]
+		err := val.UnmarshalJSON(nil)
+		require.ErrorIs(t, err, ErrEmptyInput)
+		require.ErrorIs(t, err, ErrDecode)
+
+		var decodeErr *DecodeError
+		require.ErrorAs(t, err, &decodeErr)
+		assert.Equal(t, FormatJSON, decodeErr.Format)
+		assert.Equal(t, reflect.TypeFor[int](), decodeErr.Type)
+	})
+
+	t.Run("json", func(t *testing.T) {
+		t.Parallel()
+
+		var val struct {
+			V Val[int] `json:"v"`
+		}
+
+		err := json.Unmarshal([]byte(`{"v":"abc"}`), &val)
+		require.ErrorIs(t, err, ErrDecode)
+
+		var decodeErr *DecodeError
+		require.ErrorAs(t, err, &decodeErr)
+		assert.Equal(t, FormatJSON, decodeErr.Format)
+		assert.Equal(t, reflect.TypeFor[int](), decodeErr.Type)
+
+		var typeErr *json.UnmarshalTypeError
+		require.ErrorAs(t, err, &typeErr)
+	})
+
+	t.Run("yaml", func(t *testing.T) {
+		t.Parallel()
+
+		var val struct {
+			V Val[int] `yaml:"v"`
+		}
+
+		err := yaml.Unmarshal([]byte(`v: abc`), &val)
+		require.ErrorIs(t, err, ErrDecode)
+
+		var decodeErr *DecodeError
+		require.ErrorAs(t, err, &decodeErr)
+		assert.Equal(t, FormatYAML, decodeErr.Format)
+		assert.Equal(t, reflect.TypeFor[int](), decodeErr.Type)
+	})
+
+	t.Run("sql_array", func(t *testing.T) {
+		t.Parallel()
+
+		var val Val[[]int64]
+		err := val.Scan(`{1,a}`)
+		require.ErrorIs(t, err, ErrDecode)
+
+		var decodeErr *DecodeError
+		require.ErrorAs(t, err, &decodeErr)
+		assert.Equal(t, FormatSQL, decodeErr.Format)
+	})
+}
+
+func TestScanTypeError(t *testing.T) {
+	t.Parallel()
+
+	t.Run("conversion", func(t *testing.T) {
+		t.Parallel()
+
+		var val Val[int64]
+		err := val.Scan("abc")
+		require.ErrorIs(t, err, ErrTypeMismatch)
+		require.NotErrorIs(t, err, ErrDecode)
+
+		var scanErr *ScanTypeError
+		require.ErrorAs(t, err, &scanErr)
+		assert.Equal(t, reflect.TypeFor[int64](), scanErr.Want)
+		assert.Equal(t, reflect.TypeFor[string](), scanErr.Got)
+		require.Error(t, scanErr.Err)
+	})
+
+	t.Run("array", func(t *testing.T) {
+		t.Parallel()
+
+		var val Val[[]string]
+		err := val.Scan(int64(42))
+		require.ErrorIs(t, err, ErrTypeMismatch)
+
+		var scanErr *ScanTypeError
+		require.ErrorAs(t, err, &scanErr)
+		assert.Equal(t, reflect.TypeFor[[]string](), scanErr.Want)
+		assert.Equal(t, reflect.TypeFor[int64](), scanErr.Got)
+		assert.Equal(t, "unexpected value type: can not scan int64 into []string", err.Error())
+	})
+}
+
+func TestWithPath(t *testing.T) {
+	t.Parallel()
+
+	err := WithPath(newScanTypeError[int](nil, nil), "Inner")
+	err = WithPath(err, "Outer")
+	assert.Equal(t, "field Outer.Inner: unexpected value type: can not scan nil into int", err.Error())
+
+	err = WithPath(newDecodeError[string](FormatJSON, ErrEmptyInput), "Field")
+	assert.Equal(t, "field Field: unmarshal optional value of type string from json: empty input", err.Error())
+
+	other := errors.New("other")
+	assert.Equal(t, other, WithPath(other, "Field"))
+}
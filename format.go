@@ -0,0 +1,102 @@
+package optional
+
+import (
+	"fmt"
+	"reflect"
+	"sync/atomic"
+)
+
+// PrintOptions controls how String and Format print empty values.
+type PrintOptions struct {
+	// Empty is printed instead of empty values.
+	Empty string
+}
+
+var printOptions atomic.Pointer[PrintOptions]
+
+// SetPrintOptions sets options which are used by String and Format of all
+// Val values.
+func SetPrintOptions(opts PrintOptions) {
+	printOptions.Store(&opts)
+}
+
+// GetPrintOptions returns options which are used by String and Format.
+func GetPrintOptions() PrintOptions {
+	if opts := printOptions.Load(); opts != nil {
+		return *opts
+	}
+
+	return PrintOptions{
+		Empty: "<empty>",
+	}
+}
+
+// String implements fmt.Stringer. It returns the value formatted with %v or
+// the placeholder from PrintOptions for empty values.
+func (v Val[T]) String() string {
+	if !v.hasVal {
+		return GetPrintOptions().Empty
+	}
+
+	return fmt.Sprint(v.value)
+}
+
+// String implements fmt.Stringer. See Val.String.
+func (v Quoted[T]) String() string {
+	return v.val.String()
+}
+
+// Format implements fmt.Formatter. Present values are formatted as T with the
+// same verb and flags, so %d, %.2f, %q and others work as for T. Empty values
+// are printed as the placeholder from PrintOptions. %#v is the same as GoString.
+func (v Val[T]) Format(state fmt.State, verb rune) {
+	if verb == 'v' && state.Flag('#') {
+		_, _ = fmt.Fprint(state, v.GoString())
+
+		return
+	}
+
+	formatVal(state, verb, v)
+}
+
+// GoString implements fmt.GoStringer. It returns Go syntax of the value like
+// optional.New[int](42) or optional.Empty[int]().
+func (v Val[T]) GoString() string {
+	return goString("New", "Empty", v)
+}
+
+// Format implements fmt.Formatter. See Val.Format.
+func (v Quoted[T]) Format(state fmt.State, verb rune) {
+	if verb == 'v' && state.Flag('#') {
+		_, _ = fmt.Fprint(state, v.GoString())
+
+		return
+	}
+
+	formatVal(state, verb, v.val)
+}
+
+// GoString implements fmt.GoStringer. It returns Go syntax of the value like
+// optional.NewQuoted[int](42) or optional.EmptyQuoted[int]().
+func (v Quoted[T]) GoString() string {
+	return goString("NewQuoted", "EmptyQuoted", v.val)
+}
+
+func formatVal[T any](state fmt.State, verb rune, val Val[T]) {
+	if !val.hasVal {
+		_, _ = fmt.Fprintf(state, fmt.FormatString(state, 's'), GetPrintOptions().Empty)
+
+		return
+	}
+
+	_, _ = fmt.Fprintf(state, fmt.FormatString(state, verb), val.value)
+}
+
+func goString[T any](newFn, emptyFn string, val Val[T]) string {
+	name := typeName(reflect.TypeFor[T]())
+	if !val.hasVal {
+		return fmt.Sprintf("optional.%s[%s]()", emptyFn, name)
+	}
+
+	return fmt.Sprintf("optional.%s[%s](%#v)", newFn, name, val.value)
+}
@@ -0,0 +1,109 @@
+package optional
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormat(t *testing.T) {
+	t.Parallel()
+
+	type user struct {
+		Name string
+		Age  Val[int]
+	}
+
+	table := []struct {
+		name   string
+		format string
+		val    any
+		exp    string
+	}{
+		{name: "v", format: "%v", val: New(42), exp: "42"},
+		{name: "v_empty", format: "%v", val: Empty[int](), exp: "<empty>"},
+		{name: "plus_v", format: "%+v", val: New(user{Name: "kaz"}), exp: "{Name:kaz Age:<empty>}"},
+		{name: "s", format: "%s", val: New("hi"), exp: "hi"},
+		{name: "s_empty", format: "%s", val: Empty[string](), exp: "<empty>"},
+		{name: "q", format: "%q", val: New("hi"), exp: `"hi"`},
+		{name: "d", format: "%d", val: New(42), exp: "42"},
+		{name: "d_width", format: "%05d", val: New(42), exp: "00042"},
+		{name: "d_empty_width", format: "%8d", val: Empty[int](), exp: " <empty>"},
+		{name: "f_precision", format: "%.2f", val: New(3.14159), exp: "3.14"},
+		{name: "x", format: "%x", val: New([]byte("hi")), exp: "6869"},
+		{name: "t", format: "%t", val: New(true), exp: "true"},
+		{name: "stringer", format: "%v", val: New(time.Second), exp: "1s"},
+		{name: "nested", format: "%v", val: New(New(1)), exp: "1"},
+		{name: "nested_empty", format: "%v", val: New(Empty[int]()), exp: "<empty>"},
+		{name: "slice", format: "%v", val: []Val[int]{New(1), Empty[int]()}, exp: "[1 <empty>]"},
+		{name: "quoted", format: "%v", val: NewQuoted(42), exp: "42"},
+		{name: "quoted_empty", format: "%d", val: EmptyQuoted[int](), exp: "<empty>"},
+	}
+
+	for _, row := range table {
+		t.Run(row.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, row.exp, fmt.Sprintf(row.format, row.val))
+		})
+	}
+}
+
+func TestString(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "42", New(42).String())
+	assert.Equal(t, "", New("").String())
+	assert.Equal(t, "<empty>", Empty[string]().String())
+	assert.Equal(t, "1s", New(time.Second).String())
+	assert.Equal(t, "42", NewQuoted(42).String())
+
+	var s fmt.Stringer = New(1)
+	assert.Equal(t, "1", s.String())
+}
+
+func TestGoString(t *testing.T) {
+	t.Parallel()
+
+	type user struct {
+		Name string
+	}
+
+	table := []struct {
+		name string
+		val  any
+		exp  string
+	}{
+		{name: "int", val: New(42), exp: "optional.New[int](42)"},
+		{name: "int_empty", val: Empty[int](), exp: "optional.Empty[int]()"},
+		{name: "int64", val: New[int64](42), exp: "optional.New[int64](42)"},
+		{name: "string", val: New("hi"), exp: `optional.New[string]("hi")`},
+		{name: "slice", val: New([]string{"a"}), exp: `optional.New[[]string]([]string{"a"})`},
+		{name: "struct", val: New(user{Name: "kaz"}), exp: `optional.New[optional.user](optional.user{Name:"kaz"})`},
+		{name: "nested", val: New(Empty[int]()), exp: "optional.New[optional.Val[int]](optional.Empty[int]())"},
+		{name: "quoted", val: NewQuoted(42), exp: "optional.NewQuoted[int](42)"},
+		{name: "quoted_empty", val: EmptyQuoted[float64](), exp: "optional.EmptyQuoted[float64]()"},
+	}
+
+	for _, row := range table {
+		t.Run(row.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, row.exp, fmt.Sprintf("%#v", row.val))
+			assert.Equal(t, row.exp, row.val.(fmt.GoStringer).GoString()) //nolint:forcetypeassert
+		})
+	}
+}
+
+func TestPrintOptions(t *testing.T) { //nolint:paralleltest
+	defer SetPrintOptions(GetPrintOptions())
+
+	SetPrintOptions(PrintOptions{Empty: "null"})
+
+	assert.Equal(t, "null", Empty[int]().String())
+	assert.Equal(t, "null", fmt.Sprintf("%d", Empty[int]()))
+	assert.Equal(t, "optional.Empty[int]()", fmt.Sprintf("%#v", Empty[int]()))
+	assert.Equal(t, "42", New(42).String())
+}
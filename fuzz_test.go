@@ -0,0 +1,306 @@
+package optional
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding"
+	"encoding/json"
+	"math"
+	"math/big"
+	"net/netip"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+// Fuzz targets check that codecs of Val behave exactly like codecs of T plus
+// presence. Run them with `go test -fuzz=FuzzJSON` and so on.
+
+func addFuzzSeeds(f *testing.F) {
+	f.Helper()
+
+	f.Add(true, "hi", int64(42), 4.2, true, []byte("hi"), int64(1700000000))
+	f.Add(false, "hi", int64(42), 4.2, true, []byte("hi"), int64(0))
+	f.Add(true, "", int64(0), 0.0, false, []byte{}, int64(0))
+	f.Add(true, "null", int64(math.MaxInt64), math.Copysign(0, -1), false, []byte(nil), int64(-1))
+	f.Add(true, "~", int64(math.MinInt64), math.Inf(1), false, []byte("null"), int64(math.MaxInt32))
+	f.Add(true, "\"quoted\"\n", int64(-1), math.NaN(), true, []byte{0xff}, int64(1))
+	f.Add(true, "\xff", int64(1), math.SmallestNonzeroFloat64, true, []byte("~"), int64(2))
+	f.Add(true, "\n0", int64(2), 0.5, false, []byte("\n0"), int64(3))
+}
+
+func fuzzVal[T any](present bool, val T) Val[T] {
+	if !present {
+		return Empty[T]()
+	}
+
+	return New(val)
+}
+
+func FuzzJSON(f *testing.F) {
+	addFuzzSeeds(f)
+
+	f.Fuzz(func(t *testing.T, present bool, str string, i int64, fl float64, b bool, bs []byte, sec int64) {
+		checkJSON(t, fuzzVal(present, str))
+		checkJSON(t, fuzzVal(present, i))
+		checkJSON(t, fuzzVal(present, fl))
+		checkJSON(t, fuzzVal(present, b))
+		checkJSON(t, fuzzVal(present, bs))
+		checkJSON(t, fuzzVal(present, time.Unix(sec, i%1e9).UTC()))
+		checkJSON(t, fuzzVal(present, []string{str}))
+		checkJSON(t, fuzzVal(present, &str))
+	})
+}
+
+func checkJSON[T any](t *testing.T, val Val[T]) {
+	t.Helper()
+
+	buf, err := json.Marshal(val)
+	if !val.HasVal() {
+		require.NoError(t, err)
+		require.Equal(t, "null", string(buf))
+	} else {
+		want, errWant := json.Marshal(val.Val())
+		if errWant != nil {
+			require.Error(t, err)
+
+			return
+		}
+
+		require.NoError(t, err)
+		require.Equal(t, string(want), string(buf))
+	}
+
+	var got Val[T]
+	require.NoError(t, json.Unmarshal(buf, &got))
+
+	// Present nil values are encoded as null and decoded as empty.
+	if string(buf) == "null" {
+		require.Equal(t, Empty[T](), got)
+
+		return
+	}
+
+	var plain T
+	require.NoError(t, json.Unmarshal(buf, &plain))
+	require.Equal(t, New(plain), got)
+}
+
+func FuzzYAML(f *testing.F) {
+	addFuzzSeeds(f)
+
+	f.Fuzz(func(t *testing.T, present bool, str string, i int64, fl float64, b bool, bs []byte, sec int64) {
+		checkYAML(t, fuzzVal(present, str))
+		checkYAML(t, fuzzVal(present, i))
+		checkYAML(t, fuzzVal(present && !math.IsNaN(fl), fl))
+		checkYAML(t, fuzzVal(present, b))
+		checkYAML(t, fuzzVal(present, time.Unix(sec, i%1e9).UTC()))
+		checkYAML(t, fuzzVal(present, []string{str}))
+		checkYAML(t, fuzzVal(present, map[string]int64{str: i}))
+	})
+}
+
+func checkYAML[T any](t *testing.T, val Val[T]) {
+	t.Helper()
+
+	type doc struct {
+		V Val[T] `yaml:"v"`
+	}
+
+	type plainDoc struct {
+		V *T `yaml:"v"`
+	}
+
+	buf, err := yaml.Marshal(doc{V: val})
+	require.NoError(t, err)
+
+	want, err := yaml.Marshal(plainDoc{V: val.AsPointer()})
+	require.NoError(t, err)
+	require.Equal(t, string(want), string(buf))
+
+	// yaml.v3 does not decode some of its own output, like "\n0" which is
+	// encoded as a block scalar. Val must fail exactly where *T fails.
+	var plain plainDoc
+	if errPlain := yaml.Unmarshal(buf, &plain); errPlain != nil {
+		var got doc
+		require.Error(t, yaml.Unmarshal(buf, &got))
+
+		return
+	}
+
+	var got doc
+	require.NoError(t, yaml.Unmarshal(buf, &got))
+	require.Equal(t, NewFromPointer(plain.V), got.V)
+}
+
+func FuzzSQL(f *testing.F) {
+	addFuzzSeeds(f)
+
+	f.Fuzz(func(t *testing.T, present bool, str string, i int64, fl float64, b bool, bs []byte, sec int64) {
+		checkSQL(t, fuzzVal(present, str))
+		checkSQL(t, fuzzVal(present, i))
+		checkSQL(t, fuzzVal(present, int32(i))) //nolint:gosec
+		checkSQL(t, fuzzVal(present && !math.IsNaN(fl), fl))
+		checkSQL(t, fuzzVal(present, b))
+		checkSQL(t, fuzzVal(present, bs))
+		checkSQL(t, fuzzVal(present, time.Unix(sec, i%1e9).UTC()))
+	})
+}
+
+func checkSQL[T any](t *testing.T, val Val[T]) {
+	t.Helper()
+
+	value, err := driver.DefaultParameterConverter.ConvertValue(val)
+	require.NoError(t, err)
+
+	nullValue, err := val.SQLNull().Value()
+	require.NoError(t, err)
+
+	// sql.Null converts values like int32 into driver values since Go 1.24.
+	want, err := driver.DefaultParameterConverter.ConvertValue(nullValue)
+	require.NoError(t, err)
+	require.Equal(t, want, value)
+
+	var got Val[T]
+	errGot := got.Scan(value)
+
+	var plain sql.Null[T]
+	if err := plain.Scan(value); err != nil {
+		require.Error(t, errGot)
+
+		return
+	}
+
+	require.NoError(t, errGot)
+	require.Equal(t, FromSQLNull(plain), got)
+}
+
+func FuzzText(f *testing.F) {
+	addFuzzSeeds(f)
+
+	f.Fuzz(func(t *testing.T, present bool, str string, i int64, _ float64, _ bool, bs []byte, sec int64) {
+		addr, _ := netip.AddrFromSlice(bs)
+
+		checkText(t, fuzzVal(present, time.Unix(sec, i%1e9).UTC()))
+		checkText(t, fuzzVal(present, addr))
+		checkText(t, fuzzVal(present, upper(str)))
+		checkText(t, fuzzVal(present, big.NewInt(i)))
+	})
+}
+
+func checkText[T any](t *testing.T, val Val[T]) {
+	t.Helper()
+
+	buf, err := val.MarshalText()
+	if !val.HasVal() {
+		require.NoError(t, err)
+		require.Empty(t, buf)
+	} else {
+		want, errWant := plainText(val.Val())
+		if errWant != nil {
+			require.Error(t, err)
+
+			return
+		}
+
+		require.NoError(t, err)
+		require.Equal(t, string(want), string(buf))
+	}
+
+	var got Val[T]
+	errGot := got.UnmarshalText(buf)
+
+	// Present values with empty text are decoded as empty.
+	if len(buf) == 0 {
+		require.NoError(t, errGot)
+		require.Equal(t, Empty[T](), got)
+
+		return
+	}
+
+	var plain T
+	if err := plainUnmarshalText(&plain, buf); err != nil {
+		require.Error(t, errGot)
+
+		return
+	}
+
+	require.NoError(t, errGot)
+	require.Equal(t, New(plain), got)
+}
+
+// plainText and plainUnmarshalText call text codecs of values and pointers
+// like *big.Int.
+func plainText[T any](val T) ([]byte, error) {
+	if marshaler, ok := any(val).(encoding.TextMarshaler); ok {
+		return marshaler.MarshalText() //nolint:wrapcheck
+	}
+
+	return any(&val).(encoding.TextMarshaler).MarshalText() //nolint:forcetypeassert,wrapcheck
+}
+
+func plainUnmarshalText[T any](dst *T, text []byte) error {
+	if typ := reflect.TypeFor[T](); typ.Kind() == reflect.Pointer {
+		*dst = reflect.New(typ.Elem()).Interface().(T) //nolint:forcetypeassert
+
+		return any(*dst).(encoding.TextUnmarshaler).UnmarshalText(text) //nolint:forcetypeassert,wrapcheck
+	}
+
+	return any(dst).(encoding.TextUnmarshaler).UnmarshalText(text) //nolint:forcetypeassert,wrapcheck
+}
+
+func FuzzQuoted(f *testing.F) {
+	addFuzzSeeds(f)
+
+	f.Fuzz(func(t *testing.T, present bool, _ string, i int64, fl float64, b bool, _ []byte, _ int64) {
+		checkQuoted(t, Quote(fuzzVal(present, i)))
+		checkQuoted(t, Quote(fuzzVal(present, uint8(i)))) //nolint:gosec
+		checkQuoted(t, Quote(fuzzVal(present, fl)))
+		checkQuoted(t, Quote(fuzzVal(present, float32(fl))))
+		checkQuoted(t, Quote(fuzzVal(present, b)))
+	})
+}
+
+func checkQuoted[T Quotable](t *testing.T, val Quoted[T]) {
+	t.Helper()
+
+	buf, err := json.Marshal(val)
+	if val.HasVal() && (isNaNOrInf(val.Val())) {
+		require.Error(t, err)
+
+		return
+	}
+
+	require.NoError(t, err)
+
+	var got Quoted[T]
+	require.NoError(t, json.Unmarshal(buf, &got))
+	require.Equal(t, val, got)
+
+	// Unquoted input is accepted as well.
+	var unquoted Quoted[T]
+	require.NoError(t, json.Unmarshal(must(json.Marshal(val.Unquote())), &unquoted))
+	require.Equal(t, val, unquoted)
+}
+
+func isNaNOrInf(val any) bool {
+	switch val := val.(type) {
+	case float64:
+		return math.IsNaN(val) || math.IsInf(val, 0)
+	case float32:
+		return math.IsNaN(float64(val)) || math.IsInf(float64(val), 0)
+	}
+
+	return false
+}
+
+func must[T any](val T, err error) T { //nolint:ireturn
+	if err != nil {
+		panic(err)
+	}
+
+	return val
+}
@@ -0,0 +1,50 @@
+package optional
+
+import (
+	"reflect"
+	"time"
+)
+
+// gormDataTyper is the same as schema.GormDataTypeInterface from gorm.io/gorm.
+type gormDataTyper interface {
+	GormDataType() string
+}
+
+// GormDataType reports the general data type of inner value for GORM
+// migrations. Columns of Val are nullable unless `not null` tag is set.
+func (v Val[T]) GormDataType() string {
+	if typer, ok := any(&v.value).(gormDataTyper); ok {
+		return typer.GormDataType()
+	}
+
+	typ := reflect.TypeFor[T]()
+	if typ == reflect.TypeFor[time.Time]() {
+		return "time"
+	}
+
+	// Values of these names are the same as schema.DataType constants in gorm.
+	switch typ.Kind() { //nolint:exhaustive
+	case reflect.Bool:
+		return "bool"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return "int"
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "uint"
+	case reflect.Float32, reflect.Float64:
+		return "float"
+	case reflect.String:
+		return "string"
+	case reflect.Slice:
+		if typ.Elem().Kind() == reflect.Uint8 {
+			return "bytes"
+		}
+	}
+
+	return ""
+}
+
+// GormDataType reports the general data type of inner value. See
+// Val.GormDataType.
+func (v Quoted[T]) GormDataType() string {
+	return v.val.GormDataType()
+}
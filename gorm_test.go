@@ -0,0 +1,30 @@
+package optional
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type gormJSON struct{}
+
+func (gormJSON) GormDataType() string { return "json" }
+
+type gormStatus string
+
+func TestGormDataType(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "bool", Val[bool]{}.GormDataType())
+	assert.Equal(t, "int", Val[int]{}.GormDataType())
+	assert.Equal(t, "int", Val[int64]{}.GormDataType())
+	assert.Equal(t, "uint", Val[uint32]{}.GormDataType())
+	assert.Equal(t, "float", Val[float64]{}.GormDataType())
+	assert.Equal(t, "string", Val[string]{}.GormDataType())
+	assert.Equal(t, "string", Val[gormStatus]{}.GormDataType())
+	assert.Equal(t, "time", Val[time.Time]{}.GormDataType())
+	assert.Equal(t, "bytes", Val[[]byte]{}.GormDataType())
+	assert.Equal(t, "json", Val[gormJSON]{}.GormDataType())
+	assert.Equal(t, "", Val[struct{}]{}.GormDataType())
+}
@@ -0,0 +1,55 @@
+// Package gormopt contains a GORM field type for inner types that choose
+// their column type per database.
+//
+// optional.Val reports GormDataType of the inner type by itself. Types like
+// datatypes.JSON also implement GormDBDataType, which needs gorm.io/gorm in
+// the method signature. Val of this package forwards it to the inner type:
+//
+//	type User struct {
+//		ID    int
+//		Attrs gormopt.Val[datatypes.JSON] // JSONB in PostgreSQL, JSON in MySQL
+//	}
+package gormopt
+
+import (
+	"github.com/kazhuravlev/optional"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// dbDataTyper is the same as migrator.GormDataTypeInterface from gorm.io/gorm.
+type dbDataTyper interface {
+	GormDBDataType(db *gorm.DB, field *schema.Field) string
+}
+
+// Val is optional.Val which reports GormDBDataType of the inner value.
+// All methods of optional.Val are available through embedding.
+type Val[T any] struct {
+	optional.Val[T]
+}
+
+// New returns a Val with value.
+func New[T any](val T) Val[T] {
+	return Val[T]{Val: optional.New(val)}
+}
+
+// Empty returns an empty Val.
+func Empty[T any]() Val[T] {
+	return Val[T]{}
+}
+
+// From wraps optional.Val.
+func From[T any](val optional.Val[T]) Val[T] {
+	return Val[T]{Val: val}
+}
+
+// GormDBDataType returns the column type of inner value for the database of
+// db. Empty string makes GORM use the type reported by GormDataType.
+func (v Val[T]) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	var value T
+	if typer, ok := any(&value).(dbDataTyper); ok {
+		return typer.GormDBDataType(db, field)
+	}
+
+	return ""
+}
@@ -0,0 +1,88 @@
+package gormopt
+
+import (
+	"encoding/json"
+	"sync"
+	"testing"
+
+	"github.com/kazhuravlev/optional"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"gorm.io/gorm/migrator"
+	"gorm.io/gorm/schema"
+)
+
+// jsonDoc chooses column type by database like datatypes.JSON does.
+type jsonDoc struct{}
+
+func (jsonDoc) GormDataType() string { return "json" }
+
+func (jsonDoc) GormDBDataType(db *gorm.DB, _ *schema.Field) string {
+	if db.Dialector.Name() == "postgres" {
+		return "JSONB"
+	}
+
+	return "JSON"
+}
+
+// dialector implements the parts of gorm.Dialector used by DataTypeOf.
+type dialector struct {
+	gorm.Dialector
+
+	name string
+}
+
+func (d dialector) Name() string { return d.name }
+
+func (d dialector) DataTypeOf(field *schema.Field) string {
+	return "generic:" + string(field.DataType)
+}
+
+type model struct {
+	ID    int
+	Doc   Val[jsonDoc]
+	Count Val[int]
+}
+
+func dataTypeOf(t *testing.T, dialectName, fieldName string) string {
+	t.Helper()
+
+	sch, err := schema.Parse(&model{}, &sync.Map{}, schema.NamingStrategy{})
+	require.NoError(t, err)
+
+	field := sch.LookUpField(fieldName)
+	require.NotNil(t, field)
+
+	d := dialector{name: dialectName}
+	m := migrator.Migrator{Config: migrator.Config{
+		DB:        &gorm.DB{Config: &gorm.Config{Dialector: d}},
+		Dialector: d,
+	}}
+
+	return m.DataTypeOf(field)
+}
+
+func TestGormDBDataType(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "JSONB", dataTypeOf(t, "postgres", "Doc"))
+	assert.Equal(t, "JSON", dataTypeOf(t, "mysql", "Doc"))
+	assert.Equal(t, "generic:int", dataTypeOf(t, "postgres", "Count"), "inner types without GormDBDataType use GormDataType")
+}
+
+func TestVal(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, optional.New(42), New(42).Val)
+	assert.Equal(t, optional.Empty[int](), Empty[int]().Val)
+	assert.Equal(t, New("hi"), From(optional.New("hi")))
+
+	var val Val[int]
+	require.NoError(t, val.Scan(int64(42)))
+	assert.Equal(t, New(42), val)
+
+	buf, err := json.Marshal(val)
+	require.NoError(t, err)
+	assert.JSONEq(t, `42`, string(buf))
+}
@@ -0,0 +1,147 @@
+package optional
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// gqlMarshaler is the same as graphql.Marshaler from github.com/99designs/gqlgen.
+type gqlMarshaler interface {
+	MarshalGQL(w io.Writer)
+}
+
+// gqlUnmarshaler is the same as graphql.Unmarshaler from github.com/99designs/gqlgen.
+type gqlUnmarshaler interface {
+	UnmarshalGQL(v any) error
+}
+
+// omittable is implemented by graphql.Omittable from github.com/99designs/gqlgen.
+type omittable[T any] interface {
+	ValueOK() (T, bool)
+}
+
+// MarshalGQL implements graphql.Marshaler, so Val can be bound to GraphQL
+// scalars with gqlgen. Empty value is written as null. Inner value is written
+// with its own MarshalGQL when it exists, otherwise as JSON.
+func (v Val[T]) MarshalGQL(w io.Writer) {
+	if !v.hasVal {
+		_, _ = io.WriteString(w, "null")
+
+		return
+	}
+
+	if marshaler, ok := any(&v.value).(gqlMarshaler); ok {
+		marshaler.MarshalGQL(w)
+
+		return
+	}
+
+	// graphql.Marshaler can not return an error, so gqlgen writes null for
+	// values which can not be marshaled.
+	res, err := v.MarshalJSON()
+	if err != nil {
+		res = []byte("null")
+	}
+
+	_, _ = w.Write(res)
+}
+
+// UnmarshalGQL implements graphql.Unmarshaler. gqlgen passes the input value
+// decoded from JSON, so the inner value is decoded from JSON the same way as
+// UnmarshalJSON does, or with its own UnmarshalGQL when it exists. Null
+// resets the value.
+func (v *Val[T]) UnmarshalGQL(input any) error {
+	if input == nil {
+		v.Reset()
+
+		return nil
+	}
+
+	var value T
+	if unmarshaler, ok := any(&value).(gqlUnmarshaler); ok {
+		if err := unmarshaler.UnmarshalGQL(input); err != nil {
+			return newDecodeError[T](FormatGraphQL, err)
+		}
+
+		v.Set(value)
+
+		return nil
+	}
+
+	buf, err := json.Marshal(input)
+	if err != nil {
+		return newDecodeError[T](FormatGraphQL, err)
+	}
+
+	if err := decodeJSON(buf, &value); err != nil {
+		return newDecodeError[T](FormatGraphQL, err)
+	}
+
+	v.Set(value)
+
+	return nil
+}
+
+// MarshalGQL implements graphql.Marshaler. Present values are written as
+// strings.
+func (v Quoted[T]) MarshalGQL(w io.Writer) {
+	res, err := v.MarshalJSON()
+	if err != nil {
+		res = []byte("null")
+	}
+
+	_, _ = w.Write(res)
+}
+
+// UnmarshalGQL implements graphql.Unmarshaler. Both strings and numbers are
+// accepted.
+func (v *Quoted[T]) UnmarshalGQL(input any) error {
+	if input == nil {
+		v.Reset()
+
+		return nil
+	}
+
+	buf, err := json.Marshal(input)
+	if err != nil {
+		return newDecodeError[T](FormatGraphQL, err)
+	}
+
+	return v.UnmarshalJSON(buf)
+}
+
+// FromOmittable adapt gqlgen graphql.Omittable of input field to Val of Val.
+// It keeps the difference between omitted field and explicit null:
+//
+//   - omitted field is Empty
+//   - null is New(Empty[T]())
+//   - value is New(New(value))
+//
+// Use it with fields of type graphql.Omittable[optional.Val[T]]:
+//
+//	func (r *mutationResolver) UpdateUser(ctx context.Context, in UpdateUserInput) (*User, error) {
+//		nickname := optional.FromOmittable(in.Nickname)
+//		if val, ok := nickname.Get(); ok {
+//			user.Nickname = val // set or clear
+//		}
+//	}
+func FromOmittable[T any](o omittable[Val[T]]) Val[Val[T]] {
+	val, ok := o.ValueOK()
+	if !ok {
+		return Empty[Val[T]]()
+	}
+
+	return New(val)
+}
+
+// FromOmittablePointer is the same as FromOmittable for fields of type
+// graphql.Omittable[*T], which are generated by gqlgen for nullable input
+// fields by default.
+func FromOmittablePointer[T any](o omittable[*T]) Val[Val[T]] {
+	val, ok := o.ValueOK()
+	if !ok {
+		return Empty[Val[T]]()
+	}
+
+	return New(NewFromPointer(val))
+}
@@ -0,0 +1,146 @@
+package optional
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// omittableVal is the same as graphql.Omittable from gqlgen.
+type omittableVal[T any] struct {
+	value T
+	set   bool
+}
+
+func (o omittableVal[T]) ValueOK() (T, bool) { return o.value, o.set }
+
+type gqlEnum string
+
+func (e gqlEnum) MarshalGQL(w io.Writer) { _, _ = io.WriteString(w, `"enum:`+string(e)+`"`) }
+
+func (e *gqlEnum) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return errors.New("enum must be a string")
+	}
+
+	*e = gqlEnum(str)
+
+	return nil
+}
+
+func marshalGQL(val interface{ MarshalGQL(w io.Writer) }) string {
+	var buf bytes.Buffer
+	val.MarshalGQL(&buf)
+
+	return buf.String()
+}
+
+func TestVal_MarshalGQL(t *testing.T) {
+	t.Parallel()
+
+	ts := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	assert.Equal(t, `null`, marshalGQL(Empty[int]()))
+	assert.Equal(t, `42`, marshalGQL(New(42)))
+	assert.Equal(t, `"str"`, marshalGQL(New("str")))
+	assert.Equal(t, `true`, marshalGQL(New(true)))
+	assert.Equal(t, `1.5`, marshalGQL(New(1.5)))
+	assert.Equal(t, `"2024-01-02T03:04:05Z"`, marshalGQL(New(ts)))
+	assert.Equal(t, `"enum:a"`, marshalGQL(New(gqlEnum("a"))))
+	assert.Equal(t, `null`, marshalGQL(New(func() {})))
+	assert.Equal(t, `"42"`, marshalGQL(NewQuoted(42)))
+	assert.Equal(t, `null`, marshalGQL(EmptyQuoted[int]()))
+}
+
+func TestVal_UnmarshalGQL(t *testing.T) {
+	t.Parallel()
+
+	t.Run("null", func(t *testing.T) {
+		t.Parallel()
+
+		val := New(42)
+		require.NoError(t, val.UnmarshalGQL(nil))
+		assert.Equal(t, Empty[int](), val)
+	})
+
+	t.Run("scalars", func(t *testing.T) {
+		t.Parallel()
+
+		var num Val[int64]
+		require.NoError(t, num.UnmarshalGQL(json.Number("42")))
+		assert.Equal(t, New(int64(42)), num)
+
+		require.NoError(t, num.UnmarshalGQL(int64(43)))
+		assert.Equal(t, New(int64(43)), num)
+
+		var str Val[string]
+		require.NoError(t, str.UnmarshalGQL("str"))
+		assert.Equal(t, New("str"), str)
+
+		var ts Val[time.Time]
+		require.NoError(t, ts.UnmarshalGQL("2024-01-02T03:04:05Z"))
+		assert.Equal(t, New(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)), ts)
+
+		var list Val[[]string]
+		require.NoError(t, list.UnmarshalGQL([]any{"a", "b"}))
+		assert.Equal(t, New([]string{"a", "b"}), list)
+	})
+
+	t.Run("unmarshaler", func(t *testing.T) {
+		t.Parallel()
+
+		var val Val[gqlEnum]
+		require.NoError(t, val.UnmarshalGQL("a"))
+		assert.Equal(t, New(gqlEnum("a")), val)
+
+		err := val.UnmarshalGQL(1)
+		require.ErrorIs(t, err, ErrDecode)
+		assert.Equal(t, New(gqlEnum("a")), val)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		t.Parallel()
+
+		var val Val[int]
+		err := val.UnmarshalGQL("str")
+
+		var decodeErr *DecodeError
+		require.ErrorAs(t, err, &decodeErr)
+		assert.Equal(t, FormatGraphQL, decodeErr.Format)
+		assert.False(t, val.HasVal())
+	})
+
+	t.Run("quoted", func(t *testing.T) {
+		t.Parallel()
+
+		var val Quoted[int]
+		require.NoError(t, val.UnmarshalGQL("42"))
+		assert.Equal(t, NewQuoted(42), val)
+
+		require.NoError(t, val.UnmarshalGQL(json.Number("43")))
+		assert.Equal(t, NewQuoted(43), val)
+
+		require.NoError(t, val.UnmarshalGQL(nil))
+		assert.Equal(t, EmptyQuoted[int](), val)
+	})
+}
+
+func TestFromOmittable(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, Empty[Val[string]](), FromOmittable(omittableVal[Val[string]]{}))
+	assert.Equal(t, New(Empty[string]()), FromOmittable(omittableVal[Val[string]]{set: true}))
+	assert.Equal(t, New(New("a")), FromOmittable(omittableVal[Val[string]]{value: New("a"), set: true}))
+
+	str := "a"
+	assert.Equal(t, Empty[Val[string]](), FromOmittablePointer(omittableVal[*string]{}))
+	assert.Equal(t, New(Empty[string]()), FromOmittablePointer(omittableVal[*string]{set: true}))
+	assert.Equal(t, New(New("a")), FromOmittablePointer(omittableVal[*string]{value: &str, set: true}))
+}
@@ -0,0 +1,103 @@
+// Package dbfield maps struct fields onto database columns by `db` tag.
+package dbfield
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// Field describes a struct field bound to a database column.
+type Field struct {
+	// Column is a column name from `db` tag.
+	Column string
+	// Path is a dotted path of go field names. Embedded structs are part of the path.
+	Path string
+	// Index is an index sequence for reflect.Value.FieldByIndex.
+	Index []int
+	// Optional is true for fields that track presence of value (like optional.Val).
+	Optional bool
+}
+
+type presencer interface {
+	HasVal() bool
+}
+
+var (
+	presencerType = reflect.TypeOf((*presencer)(nil)).Elem()
+	scannerType   = reflect.TypeOf((*sql.Scanner)(nil)).Elem()
+	cache         sync.Map // map[reflect.Type][]Field
+)
+
+// ErrNotStruct returned when the type is not a struct.
+var ErrNotStruct = errors.New("not a struct")
+
+// Fields returns all fields of struct type t which have `db` tag. Embedded
+// structs without tag are flattened. Fields tagged with `db:"-"` are skipped.
+func Fields(t reflect.Type) ([]Field, error) {
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("%s: %w", t, ErrNotStruct)
+	}
+
+	if res, ok := cache.Load(t); ok {
+		return res.([]Field), nil //nolint:forcetypeassert
+	}
+
+	res := collect(t, nil, "")
+
+	seen := make(map[string]string, len(res))
+	for _, f := range res {
+		if prev, ok := seen[f.Column]; ok {
+			return nil, fmt.Errorf("column %q bound to both %s and %s", f.Column, prev, f.Path)
+		}
+
+		seen[f.Column] = f.Path
+	}
+
+	cache.Store(t, res)
+
+	return res, nil
+}
+
+// IsOptional returns true when t tracks presence of value and can scan NULL into itself.
+func IsOptional(t reflect.Type) bool {
+	return t.Implements(presencerType) && reflect.PointerTo(t).Implements(scannerType)
+}
+
+func collect(t reflect.Type, index []int, prefix string) []Field {
+	var res []Field
+
+	for i := range t.NumField() {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+
+		idx := append(append([]int(nil), index...), i)
+		path := prefix + sf.Name
+
+		tag, hasTag := sf.Tag.Lookup("db")
+		if tag == "-" {
+			continue
+		}
+
+		if !hasTag || tag == "" {
+			if sf.Anonymous && sf.Type.Kind() == reflect.Struct && !IsOptional(sf.Type) {
+				res = append(res, collect(sf.Type, idx, path+".")...)
+			}
+
+			continue
+		}
+
+		res = append(res, Field{
+			Column:   tag,
+			Path:     path,
+			Index:    idx,
+			Optional: IsOptional(sf.Type),
+		})
+	}
+
+	return res
+}
@@ -0,0 +1,114 @@
+// Package fakedb is an in-memory database/sql driver for tests. It returns
+// the same result for any query and records arguments of executed statements.
+package fakedb
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"sync"
+)
+
+var errNotSupported = errors.New("not supported")
+
+// Connector implements driver.Connector.
+type Connector struct {
+	columns []string
+	rows    [][]driver.Value
+
+	mu   sync.Mutex
+	args [][]driver.Value
+}
+
+// New creates a connector which returns given columns and rows for any query.
+func New(columns []string, rows ...[]driver.Value) *Connector {
+	return &Connector{
+		columns: columns,
+		rows:    rows,
+		mu:      sync.Mutex{},
+		args:    nil,
+	}
+}
+
+// Open returns *sql.DB on top of connector.
+func (c *Connector) Open() *sql.DB {
+	return sql.OpenDB(c)
+}
+
+// Args returns arguments of all executed statements and queries.
+func (c *Connector) Args() [][]driver.Value {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return append([][]driver.Value(nil), c.args...)
+}
+
+// Connect implements driver.Connector.
+func (c *Connector) Connect(context.Context) (driver.Conn, error) {
+	return &conn{c: c}, nil
+}
+
+// Driver implements driver.Connector.
+func (c *Connector) Driver() driver.Driver {
+	return fakeDriver{}
+}
+
+func (c *Connector) record(args []driver.Value) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.args = append(c.args, args)
+}
+
+type fakeDriver struct{}
+
+func (fakeDriver) Open(string) (driver.Conn, error) { return nil, errNotSupported }
+
+type conn struct {
+	c *Connector
+}
+
+func (c *conn) Prepare(string) (driver.Stmt, error) { return &stmt{c: c.c}, nil }
+func (c *conn) Close() error                        { return nil }
+func (c *conn) Begin() (driver.Tx, error)           { return nil, errNotSupported }
+
+type stmt struct {
+	c *Connector
+}
+
+func (s *stmt) Close() error  { return nil }
+func (s *stmt) NumInput() int { return -1 }
+
+func (s *stmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.c.record(args)
+
+	return driver.RowsAffected(1), nil
+}
+
+func (s *stmt) Query(args []driver.Value) (driver.Rows, error) {
+	s.c.record(args)
+
+	return &rows{columns: s.c.columns, rows: s.c.rows, pos: 0}, nil
+}
+
+type rows struct {
+	columns []string
+	rows    [][]driver.Value
+	pos     int
+}
+
+func (r *rows) Columns() []string { return r.columns }
+func (r *rows) Close() error      { return nil }
+
+func (r *rows) Next(dest []driver.Value) error {
+	if r.pos >= len(r.rows) {
+		return io.EOF
+	}
+
+	copy(dest, r.rows[r.pos])
+	r.pos++
+
+	return nil
+}
@@ -0,0 +1,135 @@
+// Package logfield prepares optional values and structs with them for
+// structured loggers.
+package logfield
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/kazhuravlev/optional"
+	"github.com/kazhuravlev/optional/internal/valtype"
+)
+
+// Field is a struct field prepared for logging.
+type Field struct {
+	// Key is a name from `json` tag or a name of the field.
+	Key string
+	// Value is a value of the field. Optional values are resolved.
+	Value any
+	// Object is true when Value is a struct which should be logged as a
+	// nested object.
+	Object bool
+}
+
+type presencer interface {
+	HasVal() bool
+}
+
+// Resolve returns the value of optional val or the marker from
+// optional.LogOptions for empty one. It returns false when the value must be
+// dropped. Other values are returned as is.
+func Resolve(val any) (any, bool) {
+	for isOptional(val) {
+		if !val.(presencer).HasVal() { //nolint:forcetypeassert
+			opts := optional.GetLogOptions()
+			if opts.DropEmpty {
+				return nil, false
+			}
+
+			return opts.Empty.Any(), true
+		}
+
+		val = reflect.ValueOf(val).MethodByName("Val").Call(nil)[0].Interface()
+	}
+
+	return val, true
+}
+
+// Fields returns exported fields of struct or pointer to struct val. Fields
+// of embedded structs without tag are flattened, fields tagged with
+// `json:"-"` and dropped empty values are skipped.
+func Fields(val any) []Field {
+	rv := reflect.ValueOf(val)
+	for rv.Kind() == reflect.Pointer && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+
+	if rv.Kind() != reflect.Struct {
+		return nil
+	}
+
+	return collect(rv, nil)
+}
+
+// IsObject reports whether val is a struct which should be logged as a nested
+// object. Structs with their own text representation, like time.Time, are not.
+func IsObject(val any) bool {
+	if val == nil || isOptional(val) {
+		return false
+	}
+
+	switch val.(type) {
+	case json.Marshaler, encoding.TextMarshaler, fmt.Stringer, error:
+		return false
+	}
+
+	rv := reflect.ValueOf(val)
+	for rv.Kind() == reflect.Pointer && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+
+	return rv.Kind() == reflect.Struct
+}
+
+func collect(rv reflect.Value, res []Field) []Field {
+	typ := rv.Type()
+
+	for i := range typ.NumField() {
+		field := typ.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct && !isOptional(rv.Field(i).Interface()) {
+			res = collect(rv.Field(i), res)
+
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+
+		val, ok := Resolve(rv.Field(i).Interface())
+		if !ok {
+			continue
+		}
+
+		res = append(res, Field{Key: name, Value: val, Object: IsObject(val)})
+	}
+
+	return res
+}
+
+func isOptional(val any) bool {
+	if _, ok := val.(presencer); !ok {
+		return false
+	}
+
+	typ := reflect.TypeOf(val)
+	if _, ok := valtype.Elem(typ); ok {
+		return true
+	}
+
+	_, ok := valtype.QuotedElem(typ)
+
+	return ok
+}
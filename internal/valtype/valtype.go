@@ -0,0 +1,36 @@
+// Package valtype recognizes types of the optional package with reflection.
+package valtype
+
+import (
+	"reflect"
+	"strings"
+)
+
+const pkgPath = "github.com/kazhuravlev/optional"
+
+// Elem returns type of inner value when t is optional.Val.
+func Elem(t reflect.Type) (reflect.Type, bool) {
+	if !is(t, "Val[") {
+		return nil, false
+	}
+
+	field, ok := t.FieldByName("value")
+	if !ok {
+		return nil, false
+	}
+
+	return field.Type, true
+}
+
+// QuotedElem returns type of inner value when t is optional.Quoted.
+func QuotedElem(t reflect.Type) (reflect.Type, bool) {
+	if !is(t, "Quoted[") || t.NumField() != 1 {
+		return nil, false
+	}
+
+	return Elem(t.Field(0).Type)
+}
+
+func is(t reflect.Type, prefix string) bool {
+	return t.Kind() == reflect.Struct && t.PkgPath() == pkgPath && strings.HasPrefix(t.Name(), prefix)
+}
@@ -0,0 +1,24 @@
+// Package moopt converts between optional.Val and mo.Option from github.com/samber/mo.
+//
+// Both types keep presence separately from the value, so conversions in both
+// directions are lossless: mo.Some(0) becomes optional.New(0) and back.
+package moopt
+
+import (
+	"github.com/kazhuravlev/optional"
+	"github.com/samber/mo"
+)
+
+// FromOption create Val from mo.Option. None means that value not provided.
+func FromOption[T any](opt mo.Option[T]) optional.Val[T] {
+	if val, ok := opt.Get(); ok {
+		return optional.New(val)
+	}
+
+	return optional.Empty[T]()
+}
+
+// ToOption adapt value to mo.Option. It will return mo.None when value not provided.
+func ToOption[T any](val optional.Val[T]) mo.Option[T] {
+	return mo.TupleToOption(val.Get())
+}
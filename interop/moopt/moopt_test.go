@@ -0,0 +1,39 @@
+package moopt_test
+
+import (
+	"testing"
+
+	"github.com/kazhuravlev/optional"
+	"github.com/kazhuravlev/optional/interop/moopt"
+	"github.com/samber/mo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromOption(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, optional.New(42), moopt.FromOption(mo.Some(42)))
+	assert.Equal(t, optional.New(0), moopt.FromOption(mo.Some(0)))
+	assert.Equal(t, optional.Empty[int](), moopt.FromOption(mo.None[int]()))
+	assert.Equal(t, optional.Empty[int](), moopt.FromOption(mo.Option[int]{}))
+}
+
+func TestToOption(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, mo.Some("hi"), moopt.ToOption(optional.New("hi")))
+	assert.Equal(t, mo.Some(""), moopt.ToOption(optional.New("")))
+	assert.Equal(t, mo.None[string](), moopt.ToOption(optional.Empty[string]()))
+}
+
+func TestRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	for _, val := range []optional.Val[*int]{
+		optional.New(new(int)),
+		optional.New[*int](nil),
+		optional.Empty[*int](),
+	} {
+		assert.Equal(t, val, moopt.FromOption(moopt.ToOption(val)))
+	}
+}
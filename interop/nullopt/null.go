@@ -0,0 +1,112 @@
+// Package nullopt converts between optional.Val and types from github.com/guregu/null/v6.
+//
+// Types of the null package keep presence in the Valid field, exactly like
+// optional.Val does, so conversions are lossless: null.StringFrom("") becomes
+// optional.New("") and back.
+//
+// Types of the zero subpackage treat the zero value and null as equivalent.
+// Conversions keep the Valid field as is, so presence survives a round trip
+// through zero types, but zero types still encode a present zero value the same
+// way as null:
+//
+//   - FromZeroString(zero.StringFrom("")) is empty, because zero.StringFrom("") is not valid.
+//   - FromZeroString(zero.NewString("", true)) is optional.New("").
+//   - ToZeroString(optional.New("")) is valid, but it marshals to JSON as "", like null does.
+package nullopt
+
+import (
+	"time"
+
+	"github.com/guregu/null/v6"
+	"github.com/kazhuravlev/optional"
+)
+
+// FromValue create Val from null.Value. Invalid null.Value means that value not provided.
+func FromValue[T any](val null.Value[T]) optional.Val[T] {
+	return optional.FromSQLNull(val.Null)
+}
+
+// ToValue adapt value to null.Value. It will return invalid null.Value when value not provided.
+func ToValue[T any](val optional.Val[T]) null.Value[T] {
+	return null.Value[T]{Null: val.SQLNull()}
+}
+
+// FromString create Val from null.String.
+func FromString(val null.String) optional.Val[string] {
+	return optional.FromNullString(val.NullString)
+}
+
+// ToString adapt value to null.String.
+func ToString(val optional.Val[string]) null.String {
+	return null.String{NullString: optional.ToNullString(val)}
+}
+
+// FromInt create Val from null.Int.
+func FromInt(val null.Int) optional.Val[int64] {
+	return optional.FromNullInt64(val.NullInt64)
+}
+
+// ToInt adapt value to null.Int.
+func ToInt(val optional.Val[int64]) null.Int {
+	return null.Int{NullInt64: optional.ToNullInt64(val)}
+}
+
+// FromInt32 create Val from null.Int32.
+func FromInt32(val null.Int32) optional.Val[int32] {
+	return optional.FromNullInt32(val.NullInt32)
+}
+
+// ToInt32 adapt value to null.Int32.
+func ToInt32(val optional.Val[int32]) null.Int32 {
+	return null.Int32{NullInt32: optional.ToNullInt32(val)}
+}
+
+// FromInt16 create Val from null.Int16.
+func FromInt16(val null.Int16) optional.Val[int16] {
+	return optional.FromNullInt16(val.NullInt16)
+}
+
+// ToInt16 adapt value to null.Int16.
+func ToInt16(val optional.Val[int16]) null.Int16 {
+	return null.Int16{NullInt16: optional.ToNullInt16(val)}
+}
+
+// FromByte create Val from null.Byte.
+func FromByte(val null.Byte) optional.Val[byte] {
+	return optional.FromNullByte(val.NullByte)
+}
+
+// ToByte adapt value to null.Byte.
+func ToByte(val optional.Val[byte]) null.Byte {
+	return null.Byte{NullByte: optional.ToNullByte(val)}
+}
+
+// FromFloat create Val from null.Float.
+func FromFloat(val null.Float) optional.Val[float64] {
+	return optional.FromNullFloat64(val.NullFloat64)
+}
+
+// ToFloat adapt value to null.Float.
+func ToFloat(val optional.Val[float64]) null.Float {
+	return null.Float{NullFloat64: optional.ToNullFloat64(val)}
+}
+
+// FromBool create Val from null.Bool.
+func FromBool(val null.Bool) optional.Val[bool] {
+	return optional.FromNullBool(val.NullBool)
+}
+
+// ToBool adapt value to null.Bool.
+func ToBool(val optional.Val[bool]) null.Bool {
+	return null.Bool{NullBool: optional.ToNullBool(val)}
+}
+
+// FromTime create Val from null.Time.
+func FromTime(val null.Time) optional.Val[time.Time] {
+	return optional.FromNullTime(val.NullTime)
+}
+
+// ToTime adapt value to null.Time.
+func ToTime(val optional.Val[time.Time]) null.Time {
+	return null.Time{NullTime: optional.ToNullTime(val)}
+}
@@ -0,0 +1,90 @@
+package nullopt_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/guregu/null/v6"
+	"github.com/kazhuravlev/optional"
+	"github.com/kazhuravlev/optional/interop/nullopt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNullTypes(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	assert.Equal(t, optional.New("hi"), nullopt.FromString(null.StringFrom("hi")))
+	assert.Equal(t, optional.Empty[string](), nullopt.FromString(null.NewString("hi", false)))
+	assert.Equal(t, null.StringFrom("hi"), nullopt.ToString(optional.New("hi")))
+	assert.Equal(t, null.String{}, nullopt.ToString(optional.Empty[string]()))
+
+	assert.Equal(t, optional.New[int64](42), nullopt.FromInt(null.IntFrom(42)))
+	assert.Equal(t, optional.Empty[int64](), nullopt.FromInt(null.Int{}))
+	assert.Equal(t, null.IntFrom(42), nullopt.ToInt(optional.New[int64](42)))
+	assert.Equal(t, null.Int{}, nullopt.ToInt(optional.Empty[int64]()))
+
+	assert.Equal(t, optional.New[int32](42), nullopt.FromInt32(null.Int32From(42)))
+	assert.Equal(t, optional.Empty[int32](), nullopt.FromInt32(null.Int32{}))
+	assert.Equal(t, null.Int32From(42), nullopt.ToInt32(optional.New[int32](42)))
+	assert.Equal(t, null.Int32{}, nullopt.ToInt32(optional.Empty[int32]()))
+
+	assert.Equal(t, optional.New[int16](42), nullopt.FromInt16(null.Int16From(42)))
+	assert.Equal(t, optional.Empty[int16](), nullopt.FromInt16(null.Int16{}))
+	assert.Equal(t, null.Int16From(42), nullopt.ToInt16(optional.New[int16](42)))
+	assert.Equal(t, null.Int16{}, nullopt.ToInt16(optional.Empty[int16]()))
+
+	assert.Equal(t, optional.New[byte](42), nullopt.FromByte(null.ByteFrom(42)))
+	assert.Equal(t, optional.Empty[byte](), nullopt.FromByte(null.Byte{}))
+	assert.Equal(t, null.ByteFrom(42), nullopt.ToByte(optional.New[byte](42)))
+	assert.Equal(t, null.Byte{}, nullopt.ToByte(optional.Empty[byte]()))
+
+	assert.Equal(t, optional.New(4.2), nullopt.FromFloat(null.FloatFrom(4.2)))
+	assert.Equal(t, optional.Empty[float64](), nullopt.FromFloat(null.Float{}))
+	assert.Equal(t, null.FloatFrom(4.2), nullopt.ToFloat(optional.New(4.2)))
+	assert.Equal(t, null.Float{}, nullopt.ToFloat(optional.Empty[float64]()))
+
+	assert.Equal(t, optional.New(true), nullopt.FromBool(null.BoolFrom(true)))
+	assert.Equal(t, optional.Empty[bool](), nullopt.FromBool(null.Bool{}))
+	assert.Equal(t, null.BoolFrom(true), nullopt.ToBool(optional.New(true)))
+	assert.Equal(t, null.Bool{}, nullopt.ToBool(optional.Empty[bool]()))
+
+	assert.Equal(t, optional.New(now), nullopt.FromTime(null.TimeFrom(now)))
+	assert.Equal(t, optional.Empty[time.Time](), nullopt.FromTime(null.Time{}))
+	assert.Equal(t, null.TimeFrom(now), nullopt.ToTime(optional.New(now)))
+	assert.Equal(t, null.Time{}, nullopt.ToTime(optional.Empty[time.Time]()))
+
+	assert.Equal(t, optional.New([]int{1}), nullopt.FromValue(null.ValueFrom([]int{1})))
+	assert.Equal(t, optional.Empty[[]int](), nullopt.FromValue(null.Value[[]int]{}))
+	assert.Equal(t, null.ValueFrom([]int{1}), nullopt.ToValue(optional.New([]int{1})))
+	assert.Equal(t, null.Value[[]int]{}, nullopt.ToValue(optional.Empty[[]int]()))
+}
+
+func TestNullZeroValues(t *testing.T) {
+	t.Parallel()
+
+	// The null package keeps zero values apart from null.
+	assert.Equal(t, optional.New(""), nullopt.FromString(null.StringFrom("")))
+	assert.Equal(t, optional.New[int64](0), nullopt.FromInt(null.IntFrom(0)))
+	assert.Equal(t, optional.New(false), nullopt.FromBool(null.BoolFrom(false)))
+	assert.Equal(t, optional.New(time.Time{}), nullopt.FromTime(null.TimeFrom(time.Time{})))
+
+	assert.Equal(t, null.StringFrom(""), nullopt.ToString(optional.New("")))
+	assert.Equal(t, null.IntFrom(0), nullopt.ToInt(optional.New[int64](0)))
+
+	// Value of invalid null type is discarded.
+	assert.Equal(t, optional.Empty[int64](), nullopt.FromInt(null.NewInt(42, false)))
+
+	// Encoding keeps presence as well.
+	for _, val := range []optional.Val[string]{optional.New(""), optional.Empty[string]()} {
+		want, err := json.Marshal(val)
+		require.NoError(t, err)
+
+		got, err := json.Marshal(nullopt.ToString(val))
+		require.NoError(t, err)
+		assert.JSONEq(t, string(want), string(got))
+	}
+}
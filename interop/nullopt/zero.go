@@ -0,0 +1,98 @@
+package nullopt
+
+import (
+	"time"
+
+	"github.com/guregu/null/v6/zero"
+	"github.com/kazhuravlev/optional"
+)
+
+// FromZeroValue create Val from zero.Value. Invalid zero.Value means that value not provided.
+func FromZeroValue[T comparable](val zero.Value[T]) optional.Val[T] {
+	return optional.FromSQLNull(val.Null)
+}
+
+// ToZeroValue adapt value to zero.Value. Present zero value stays valid.
+func ToZeroValue[T comparable](val optional.Val[T]) zero.Value[T] {
+	return zero.Value[T]{Null: val.SQLNull()}
+}
+
+// FromZeroString create Val from zero.String.
+func FromZeroString(val zero.String) optional.Val[string] {
+	return optional.FromNullString(val.NullString)
+}
+
+// ToZeroString adapt value to zero.String. Present zero value stays valid.
+func ToZeroString(val optional.Val[string]) zero.String {
+	return zero.String{NullString: optional.ToNullString(val)}
+}
+
+// FromZeroInt create Val from zero.Int.
+func FromZeroInt(val zero.Int) optional.Val[int64] {
+	return optional.FromNullInt64(val.NullInt64)
+}
+
+// ToZeroInt adapt value to zero.Int. Present zero value stays valid.
+func ToZeroInt(val optional.Val[int64]) zero.Int {
+	return zero.Int{NullInt64: optional.ToNullInt64(val)}
+}
+
+// FromZeroInt32 create Val from zero.Int32.
+func FromZeroInt32(val zero.Int32) optional.Val[int32] {
+	return optional.FromNullInt32(val.NullInt32)
+}
+
+// ToZeroInt32 adapt value to zero.Int32. Present zero value stays valid.
+func ToZeroInt32(val optional.Val[int32]) zero.Int32 {
+	return zero.Int32{NullInt32: optional.ToNullInt32(val)}
+}
+
+// FromZeroInt16 create Val from zero.Int16.
+func FromZeroInt16(val zero.Int16) optional.Val[int16] {
+	return optional.FromNullInt16(val.NullInt16)
+}
+
+// ToZeroInt16 adapt value to zero.Int16. Present zero value stays valid.
+func ToZeroInt16(val optional.Val[int16]) zero.Int16 {
+	return zero.Int16{NullInt16: optional.ToNullInt16(val)}
+}
+
+// FromZeroByte create Val from zero.Byte.
+func FromZeroByte(val zero.Byte) optional.Val[byte] {
+	return optional.FromNullByte(val.NullByte)
+}
+
+// ToZeroByte adapt value to zero.Byte. Present zero value stays valid.
+func ToZeroByte(val optional.Val[byte]) zero.Byte {
+	return zero.Byte{NullByte: optional.ToNullByte(val)}
+}
+
+// FromZeroFloat create Val from zero.Float.
+func FromZeroFloat(val zero.Float) optional.Val[float64] {
+	return optional.FromNullFloat64(val.NullFloat64)
+}
+
+// ToZeroFloat adapt value to zero.Float. Present zero value stays valid.
+func ToZeroFloat(val optional.Val[float64]) zero.Float {
+	return zero.Float{NullFloat64: optional.ToNullFloat64(val)}
+}
+
+// FromZeroBool create Val from zero.Bool.
+func FromZeroBool(val zero.Bool) optional.Val[bool] {
+	return optional.FromNullBool(val.NullBool)
+}
+
+// ToZeroBool adapt value to zero.Bool. Present zero value stays valid.
+func ToZeroBool(val optional.Val[bool]) zero.Bool {
+	return zero.Bool{NullBool: optional.ToNullBool(val)}
+}
+
+// FromZeroTime create Val from zero.Time.
+func FromZeroTime(val zero.Time) optional.Val[time.Time] {
+	return optional.FromNullTime(val.NullTime)
+}
+
+// ToZeroTime adapt value to zero.Time. Present zero value stays valid.
+func ToZeroTime(val optional.Val[time.Time]) zero.Time {
+	return zero.Time{NullTime: optional.ToNullTime(val)}
+}
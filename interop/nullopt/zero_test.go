@@ -0,0 +1,95 @@
+package nullopt_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/guregu/null/v6/zero"
+	"github.com/kazhuravlev/optional"
+	"github.com/kazhuravlev/optional/interop/nullopt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestZeroTypes(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	assert.Equal(t, optional.New("hi"), nullopt.FromZeroString(zero.StringFrom("hi")))
+	assert.Equal(t, optional.Empty[string](), nullopt.FromZeroString(zero.String{}))
+	assert.Equal(t, zero.StringFrom("hi"), nullopt.ToZeroString(optional.New("hi")))
+	assert.Equal(t, zero.String{}, nullopt.ToZeroString(optional.Empty[string]()))
+
+	assert.Equal(t, optional.New[int64](42), nullopt.FromZeroInt(zero.IntFrom(42)))
+	assert.Equal(t, optional.Empty[int64](), nullopt.FromZeroInt(zero.Int{}))
+	assert.Equal(t, zero.IntFrom(42), nullopt.ToZeroInt(optional.New[int64](42)))
+	assert.Equal(t, zero.Int{}, nullopt.ToZeroInt(optional.Empty[int64]()))
+
+	assert.Equal(t, optional.New[int32](42), nullopt.FromZeroInt32(zero.Int32From(42)))
+	assert.Equal(t, optional.Empty[int32](), nullopt.FromZeroInt32(zero.Int32{}))
+	assert.Equal(t, zero.Int32From(42), nullopt.ToZeroInt32(optional.New[int32](42)))
+	assert.Equal(t, zero.Int32{}, nullopt.ToZeroInt32(optional.Empty[int32]()))
+
+	assert.Equal(t, optional.New[int16](42), nullopt.FromZeroInt16(zero.Int16From(42)))
+	assert.Equal(t, optional.Empty[int16](), nullopt.FromZeroInt16(zero.Int16{}))
+	assert.Equal(t, zero.Int16From(42), nullopt.ToZeroInt16(optional.New[int16](42)))
+	assert.Equal(t, zero.Int16{}, nullopt.ToZeroInt16(optional.Empty[int16]()))
+
+	assert.Equal(t, optional.New[byte](42), nullopt.FromZeroByte(zero.ByteFrom(42)))
+	assert.Equal(t, optional.Empty[byte](), nullopt.FromZeroByte(zero.Byte{}))
+	assert.Equal(t, zero.ByteFrom(42), nullopt.ToZeroByte(optional.New[byte](42)))
+	assert.Equal(t, zero.Byte{}, nullopt.ToZeroByte(optional.Empty[byte]()))
+
+	assert.Equal(t, optional.New(4.2), nullopt.FromZeroFloat(zero.FloatFrom(4.2)))
+	assert.Equal(t, optional.Empty[float64](), nullopt.FromZeroFloat(zero.Float{}))
+	assert.Equal(t, zero.FloatFrom(4.2), nullopt.ToZeroFloat(optional.New(4.2)))
+	assert.Equal(t, zero.Float{}, nullopt.ToZeroFloat(optional.Empty[float64]()))
+
+	assert.Equal(t, optional.New(true), nullopt.FromZeroBool(zero.BoolFrom(true)))
+	assert.Equal(t, optional.Empty[bool](), nullopt.FromZeroBool(zero.Bool{}))
+	assert.Equal(t, zero.BoolFrom(true), nullopt.ToZeroBool(optional.New(true)))
+	assert.Equal(t, zero.Bool{}, nullopt.ToZeroBool(optional.Empty[bool]()))
+
+	assert.Equal(t, optional.New(now), nullopt.FromZeroTime(zero.TimeFrom(now)))
+	assert.Equal(t, optional.Empty[time.Time](), nullopt.FromZeroTime(zero.Time{}))
+	assert.Equal(t, zero.TimeFrom(now), nullopt.ToZeroTime(optional.New(now)))
+	assert.Equal(t, zero.Time{}, nullopt.ToZeroTime(optional.Empty[time.Time]()))
+
+	assert.Equal(t, optional.New(42), nullopt.FromZeroValue(zero.ValueFrom(42)))
+	assert.Equal(t, optional.Empty[int](), nullopt.FromZeroValue(zero.Value[int]{}))
+	assert.Equal(t, zero.ValueFrom(42), nullopt.ToZeroValue(optional.New(42)))
+	assert.Equal(t, zero.Value[int]{}, nullopt.ToZeroValue(optional.Empty[int]()))
+}
+
+func TestZeroZeroValues(t *testing.T) {
+	t.Parallel()
+
+	// Constructors of the zero package make zero values invalid.
+	assert.Equal(t, optional.Empty[string](), nullopt.FromZeroString(zero.StringFrom("")))
+	assert.Equal(t, optional.Empty[int64](), nullopt.FromZeroInt(zero.IntFrom(0)))
+	assert.Equal(t, optional.Empty[bool](), nullopt.FromZeroBool(zero.BoolFrom(false)))
+	assert.Equal(t, optional.Empty[time.Time](), nullopt.FromZeroTime(zero.TimeFrom(time.Time{})))
+
+	// Valid zero values are kept as present.
+	assert.Equal(t, optional.New(""), nullopt.FromZeroString(zero.NewString("", true)))
+	assert.Equal(t, optional.New[int64](0), nullopt.FromZeroInt(zero.NewInt(0, true)))
+
+	// Conversion keeps presence of zero values, so a round trip is lossless.
+	for _, val := range []optional.Val[string]{optional.New(""), optional.New("hi"), optional.Empty[string]()} {
+		assert.Equal(t, val, nullopt.FromZeroString(nullopt.ToZeroString(val)))
+	}
+
+	assert.True(t, nullopt.ToZeroString(optional.New("")).Valid)
+
+	// But zero types encode present zero value the same way as null.
+	present, err := json.Marshal(nullopt.ToZeroString(optional.New("")))
+	require.NoError(t, err)
+
+	absent, err := json.Marshal(nullopt.ToZeroString(optional.Empty[string]()))
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `""`, string(present))
+	assert.JSONEq(t, string(absent), string(present))
+}
@@ -0,0 +1,39 @@
+// Package ptropt converts between optional.Val and pointers in the style of
+// github.com/AlekSi/pointer.
+//
+// A nil pointer means that value not provided. Pointers returned by this
+// package never alias the value stored in Val and the other way round.
+package ptropt
+
+import (
+	"github.com/AlekSi/pointer"
+	"github.com/kazhuravlev/optional"
+)
+
+// FromPointer create Val from a copy of the pointed value. Nil means that value not provided.
+func FromPointer[T any](ptr *T) optional.Val[T] {
+	return optional.NewFromPointer(ptr)
+}
+
+// ToPointer returns a pointer to a copy of the value. It will return nil when value not provided.
+func ToPointer[T any](val optional.Val[T]) *T {
+	if v, ok := val.Get(); ok {
+		return pointer.To(v)
+	}
+
+	return nil
+}
+
+// FromNonZero create Val which is empty for the zero value, like pointer.ToOrNil does.
+// Values with `IsZero() bool` method (for example, time.Time) use it to detect the zero value.
+//
+// Unlike other conversions it conflates the zero value and absence of value:
+// FromNonZero(0) is empty.
+func FromNonZero[T comparable](val T) optional.Val[T] {
+	return optional.NewFromPointer(pointer.ToOrNil(val))
+}
+
+// ValueOrZero returns the value or the zero value of T, like pointer.Get does.
+func ValueOrZero[T any](val optional.Val[T]) T { //nolint:ireturn
+	return pointer.Get(val.AsPointer())
+}
@@ -0,0 +1,63 @@
+package ptropt_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/AlekSi/pointer"
+	"github.com/kazhuravlev/optional"
+	"github.com/kazhuravlev/optional/interop/ptropt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFromPointer(t *testing.T) {
+	t.Parallel()
+
+	ptr := pointer.To(42)
+	val := ptropt.FromPointer(ptr)
+	assert.Equal(t, optional.New(42), val)
+
+	*ptr = 1
+	assert.Equal(t, optional.New(42), val, "must not alias the pointer")
+
+	assert.Equal(t, optional.New(0), ptropt.FromPointer(pointer.To(0)))
+	assert.Equal(t, optional.Empty[int](), ptropt.FromPointer[int](nil))
+}
+
+func TestToPointer(t *testing.T) {
+	t.Parallel()
+
+	assert.Nil(t, ptropt.ToPointer(optional.Empty[int]()))
+
+	val := optional.New(42)
+	ptr := ptropt.ToPointer(val)
+	require.NotNil(t, ptr)
+	assert.Equal(t, 42, *ptr)
+
+	*ptr = 1
+	assert.Equal(t, optional.New(42), val, "must not alias the value")
+
+	zero := ptropt.ToPointer(optional.New(0))
+	require.NotNil(t, zero)
+	assert.Equal(t, 0, *zero)
+}
+
+func TestFromNonZero(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, optional.New(42), ptropt.FromNonZero(42))
+	assert.Equal(t, optional.Empty[int](), ptropt.FromNonZero(0))
+	assert.Equal(t, optional.Empty[string](), ptropt.FromNonZero(""))
+	assert.Equal(t, optional.Empty[time.Time](), ptropt.FromNonZero(time.Time{}))
+
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	assert.Equal(t, optional.New(now), ptropt.FromNonZero(now))
+}
+
+func TestValueOrZero(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, 42, ptropt.ValueOrZero(optional.New(42)))
+	assert.Equal(t, 0, ptropt.ValueOrZero(optional.Empty[int]()))
+}
@@ -3,25 +3,61 @@ package optional
 import (
 	"bytes"
 	"encoding/json"
-	"errors"
 	"fmt"
+	"sync/atomic"
 )
 
+// JSONDecodeOptions controls how UnmarshalJSON decodes the inner value.
+type JSONDecodeOptions struct {
+	// UseNumber is the same as json.Decoder.UseNumber.
+	UseNumber bool
+	// DisallowUnknownFields is the same as json.Decoder.DisallowUnknownFields.
+	DisallowUnknownFields bool
+}
+
+var jsonDecodeOptions atomic.Pointer[JSONDecodeOptions]
+
+// SetJSONDecodeOptions sets options which are used by UnmarshalJSON of all
+// Val values. encoding/json does not pass settings of json.Decoder into
+// json.Unmarshaler, so set here the same options as for the decoder.
+func SetJSONDecodeOptions(opts JSONDecodeOptions) {
+	jsonDecodeOptions.Store(&opts)
+}
+
+// GetJSONDecodeOptions returns options which are used by UnmarshalJSON.
+func GetJSONDecodeOptions() JSONDecodeOptions {
+	if opts := jsonDecodeOptions.Load(); opts != nil {
+		return *opts
+	}
+
+	return JSONDecodeOptions{
+		UseNumber:             false,
+		DisallowUnknownFields: false,
+	}
+}
+
 // UnmarshalJSON implements json.Unmarshaler.
 func (v *Val[T]) UnmarshalJSON(buf []byte) error {
 	switch len(buf) {
 	case 0:
-		return errors.New("empty json input")
+		return newDecodeError[T](FormatJSON, ErrEmptyInput)
 	case 4:
 		if bytes.Equal(buf, []byte("null")) {
-			v.hasVal = false
+			v.value, v.hasVal = *new(T), false
 
 			return nil
 		}
 	}
 
-	if err := json.Unmarshal(buf, &v.value); err != nil {
-		return fmt.Errorf("unmarshal optional value: %w", err)
+	if err := decodeJSON(buf, &v.value); err != nil {
+		decodeErr := newDecodeError[T](FormatJSON, err)
+
+		// Field of errors from nested Val refers to their own values.
+		if typeErr, ok := err.(*json.UnmarshalTypeError); ok { //nolint:errorlint
+			decodeErr.Path = typeErr.Field
+		}
+
+		return decodeErr
 	}
 
 	v.hasVal = true
@@ -35,10 +71,29 @@ func (v Val[T]) MarshalJSON() ([]byte, error) {
 		return []byte("null"), nil
 	}
 
-	res, err := json.Marshal(v.value)
+	// Address of value is used to call marshalers of T with pointer receiver.
+	res, err := json.Marshal(&v.value)
 	if err != nil {
 		return nil, fmt.Errorf("marshal json: %w", err)
 	}
 
 	return res, nil
 }
+
+func decodeJSON(buf []byte, dst any) error {
+	opts := GetJSONDecodeOptions()
+	if !opts.UseNumber && !opts.DisallowUnknownFields {
+		return json.Unmarshal(buf, dst) //nolint:wrapcheck
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(buf))
+	if opts.UseNumber {
+		dec.UseNumber()
+	}
+
+	if opts.DisallowUnknownFields {
+		dec.DisallowUnknownFields()
+	}
+
+	return dec.Decode(dst) //nolint:wrapcheck
+}
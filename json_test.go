@@ -297,3 +297,77 @@ func TestJSONComplexTypes(t *testing.T) {
 		require.Equal(t, "value", result["custom"])
 	})
 }
+
+// TestJSONDecodeOptions changes global options, so it must not run in parallel.
+func TestJSONDecodeOptions(t *testing.T) { //nolint:paralleltest
+	type Inner struct {
+		Name string `json:"name"`
+	}
+
+	type payload struct {
+		Inner  Val[Inner] `json:"inner"`
+		Number Val[any]   `json:"number"`
+	}
+
+	const input = `{"inner": {"name": "test", "unknown": 1}, "number": 42}`
+
+	defer SetJSONDecodeOptions(GetJSONDecodeOptions())
+
+	t.Run("default", func(t *testing.T) {
+		SetJSONDecodeOptions(JSONDecodeOptions{})
+
+		var val payload
+		require.NoError(t, json.Unmarshal([]byte(input), &val))
+		assert.Equal(t, New(Inner{Name: "test"}), val.Inner)
+		assert.Equal(t, New[any](float64(42)), val.Number)
+	})
+
+	t.Run("disallow_unknown_fields", func(t *testing.T) {
+		SetJSONDecodeOptions(JSONDecodeOptions{DisallowUnknownFields: true})
+
+		var val payload
+		err := json.Unmarshal([]byte(input), &val)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `unknown field "unknown"`)
+
+		dec := json.NewDecoder(strings.NewReader(`{"inner": {"name": "test"}, "number": 42}`))
+		dec.DisallowUnknownFields()
+		require.NoError(t, dec.Decode(&val))
+		assert.Equal(t, New(Inner{Name: "test"}), val.Inner)
+	})
+
+	t.Run("use_number", func(t *testing.T) {
+		SetJSONDecodeOptions(JSONDecodeOptions{UseNumber: true})
+
+		var val payload
+		require.NoError(t, json.Unmarshal([]byte(input), &val))
+		assert.Equal(t, New[any](json.Number("42")), val.Number)
+	})
+}
+
+// ptrJSON implements json.Marshaler with pointer receiver.
+type ptrJSON struct {
+	Name string
+}
+
+func (p *ptrJSON) MarshalJSON() ([]byte, error) {
+	return json.Marshal("custom:" + p.Name)
+}
+
+func TestJSONPointerReceiverMarshaler(t *testing.T) {
+	t.Parallel()
+
+	type payload struct {
+		Bare ptrJSON      `json:"bare"`
+		V    Val[ptrJSON] `json:"v"`
+		E    Val[ptrJSON] `json:"e"`
+	}
+
+	res, err := json.Marshal(&payload{
+		Bare: ptrJSON{Name: "a"},
+		V:    New(ptrJSON{Name: "a"}),
+		E:    Empty[ptrJSON](),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, `{"bare":"custom:a","v":"custom:a","e":null}`, string(res))
+}
@@ -0,0 +1,108 @@
+//go:build goexperiment.jsonv2 && go1.25
+
+package optional
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// MarshalJSONTo implements json.MarshalerTo from encoding/json/v2. The inner
+// value is written directly into the caller's encoder, so all encoder options
+// (like formatting and omitzero handling) are preserved.
+func (v Val[T]) MarshalJSONTo(enc *jsonEncoder) error {
+	if !v.hasVal {
+		return enc.WriteToken(jsonNull) //nolint:wrapcheck
+	}
+
+	if err := jsonMarshalEncode(enc, &v.value); err != nil {
+		return fmt.Errorf("marshal json: %w", err)
+	}
+
+	return nil
+}
+
+// UnmarshalJSONFrom implements json.UnmarshalerFrom from encoding/json/v2.
+// The inner value is read from the caller's decoder, so all decoder options
+// (like RejectUnknownMembers) are applied to nested values. When options are
+// set by SetJSONDecodeOptions, the value is decoded by UnmarshalJSON instead.
+func (v *Val[T]) UnmarshalJSONFrom(dec *jsonDecoder) error {
+	if opts := GetJSONDecodeOptions(); opts.UseNumber || opts.DisallowUnknownFields {
+		buf, err := dec.ReadValue()
+		if err != nil {
+			return newDecodeError[T](FormatJSON, err)
+		}
+
+		return v.UnmarshalJSON(buf)
+	}
+
+	if dec.PeekKind() == 'n' {
+		if _, err := dec.ReadToken(); err != nil {
+			return newDecodeError[T](FormatJSON, err)
+		}
+
+		v.value, v.hasVal = *new(T), false
+
+		return nil
+	}
+
+	depth := dec.StackDepth()
+	if err := jsonUnmarshalDecode(dec, &v.value); err != nil {
+		v.value, v.hasVal = *new(T), false
+
+		decodeErr := newDecodeError[T](FormatJSON, err)
+		decodeErr.Path = jsonErrorPath(err, depth)
+
+		return decodeErr
+	}
+
+	v.hasVal = true
+
+	return nil
+}
+
+// MarshalJSONTo implements json.MarshalerTo from encoding/json/v2.
+func (v Quoted[T]) MarshalJSONTo(enc *jsonEncoder) error {
+	buf, err := v.MarshalJSON()
+	if err != nil {
+		return err
+	}
+
+	return enc.WriteValue(buf) //nolint:wrapcheck
+}
+
+// UnmarshalJSONFrom implements json.UnmarshalerFrom from encoding/json/v2.
+func (v *Quoted[T]) UnmarshalJSONFrom(dec *jsonDecoder) error {
+	buf, err := dec.ReadValue()
+	if err != nil {
+		return newDecodeError[T](FormatJSON, err)
+	}
+
+	return v.UnmarshalJSON(buf)
+}
+
+// jsonErrorPath returns the path of the failed value inside the value at
+// depth of the document. Errors of encoding/json/v2 refer to the document.
+func jsonErrorPath(err error, depth int) string {
+	var (
+		path    []string
+		typeErr *json.UnmarshalTypeError
+		semErr  *jsonSemanticError
+	)
+
+	switch {
+	case errors.As(err, &semErr):
+		path = slices.Collect(semErr.JSONPointer.Tokens())
+	case errors.As(err, &typeErr) && typeErr.Field != "":
+		path = strings.Split(typeErr.Field, ".")
+	}
+
+	if len(path) <= depth {
+		return ""
+	}
+
+	return strings.Join(path[depth:], ".")
+}
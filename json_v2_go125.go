@@ -0,0 +1,26 @@
+//go:build goexperiment.jsonv2 && go1.25 && !go1.27
+
+package optional
+
+import (
+	"encoding/json/jsontext"
+	jsonv2 "encoding/json/v2"
+)
+
+// encoding/json/v2 is experimental in Go 1.25 and 1.26. Since Go 1.27 it is
+// a stable API, and go vet reports its use in files built for older Go. The
+// names below keep json_v2.go free of version tags; json_v2_go127.go is the
+// same file for Go 1.27+.
+
+type (
+	jsonEncoder = jsontext.Encoder
+	jsonDecoder = jsontext.Decoder
+
+	jsonSemanticError = jsonv2.SemanticError
+)
+
+var (
+	jsonNull            = jsontext.Null
+	jsonMarshalEncode   = jsonv2.MarshalEncode
+	jsonUnmarshalDecode = jsonv2.UnmarshalDecode
+)
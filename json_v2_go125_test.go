@@ -0,0 +1,17 @@
+//go:build goexperiment.jsonv2 && go1.25 && !go1.27
+
+package optional
+
+import (
+	"encoding/json/jsontext"
+	jsonv2 "encoding/json/v2"
+)
+
+// See json_v2_go125.go.
+
+var (
+	jsonv2Marshal            = jsonv2.Marshal
+	jsonv2Unmarshal          = jsonv2.Unmarshal
+	jsonMultiline            = jsontext.Multiline
+	jsonRejectUnknownMembers = jsonv2.RejectUnknownMembers
+)
@@ -0,0 +1,23 @@
+//go:build goexperiment.jsonv2 && go1.27
+
+package optional
+
+import (
+	"encoding/json/jsontext"
+	jsonv2 "encoding/json/v2"
+)
+
+// The same as json_v2_go125.go for Go 1.27+, where encoding/json/v2 is stable.
+
+type (
+	jsonEncoder = jsontext.Encoder
+	jsonDecoder = jsontext.Decoder
+
+	jsonSemanticError = jsonv2.SemanticError
+)
+
+var (
+	jsonNull            = jsontext.Null
+	jsonMarshalEncode   = jsonv2.MarshalEncode
+	jsonUnmarshalDecode = jsonv2.UnmarshalDecode
+)
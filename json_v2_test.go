@@ -0,0 +1,219 @@
+//go:build goexperiment.jsonv2 && go1.25
+
+package optional
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONv2Marshal(t *testing.T) {
+	t.Parallel()
+
+	type payload struct {
+		A Val[int]      `json:"a"`
+		B Val[int]      `json:"b,omitzero"`
+		C Val[[]string] `json:"c,omitzero"`
+		D Val[string]   `json:"d,omitempty"`
+	}
+
+	t.Run("values", func(t *testing.T) {
+		t.Parallel()
+
+		res, err := jsonv2Marshal(payload{
+			A: New(0),
+			B: New(0),
+			C: New([]string{"hi"}),
+			D: New(""),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, `{"a":0,"b":0,"c":["hi"]}`, string(res))
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		t.Parallel()
+
+		res, err := jsonv2Marshal(payload{})
+		require.NoError(t, err)
+		assert.Equal(t, `{"a":null}`, string(res))
+	})
+
+	t.Run("caller_options", func(t *testing.T) {
+		t.Parallel()
+
+		res, err := jsonv2Marshal(New(map[string]int{"a": 1}), jsonMultiline(true))
+		require.NoError(t, err)
+		assert.Equal(t, "{\n\t\"a\": 1\n}", string(res))
+	})
+}
+
+func TestJSONv2Unmarshal(t *testing.T) {
+	t.Parallel()
+
+	type Inner struct {
+		Name string `json:"name"`
+	}
+
+	type payload struct {
+		V Val[Inner] `json:"v"`
+	}
+
+	t.Run("values", func(t *testing.T) {
+		t.Parallel()
+
+		var val payload
+		require.NoError(t, jsonv2Unmarshal([]byte(`{"v":{"name":"test"}}`), &val))
+		assert.Equal(t, New(Inner{Name: "test"}), val.V)
+
+		require.NoError(t, jsonv2Unmarshal([]byte(`{"v":null}`), &val))
+		assert.Equal(t, Empty[Inner](), val.V)
+	})
+
+	t.Run("reject_unknown_members", func(t *testing.T) {
+		t.Parallel()
+
+		input := []byte(`{"v":{"name":"test","unknown":1}}`)
+
+		var val payload
+		require.NoError(t, jsonv2Unmarshal(input, &val))
+		require.Error(t, jsonv2Unmarshal(input, &val, jsonRejectUnknownMembers(true)))
+	})
+
+	t.Run("v1_decoder_settings", func(t *testing.T) {
+		t.Parallel()
+
+		dec := json.NewDecoder(strings.NewReader(`{"v":{"name":"test","unknown":1}}`))
+		dec.DisallowUnknownFields()
+
+		var val payload
+		require.Error(t, dec.Decode(&val))
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		t.Parallel()
+
+		val := New(42)
+		err := jsonv2Unmarshal([]byte(`"not_a_number"`), &val)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "unmarshal optional value")
+		assert.Equal(t, Empty[int](), val)
+	})
+
+	t.Run("error_path", func(t *testing.T) {
+		t.Parallel()
+
+		type inner struct {
+			Items []int `json:"items"`
+		}
+
+		var val struct {
+			List []Val[inner] `json:"list"`
+		}
+
+		err := jsonv2Unmarshal([]byte(`{"list":[null,{"items":[1,"x"]}]}`), &val)
+
+		var decodeErr *DecodeError
+		require.ErrorAs(t, err, &decodeErr)
+		assert.Equal(t, "items.1", decodeErr.Path, "path is relative to the value")
+	})
+}
+
+// bytesVal has only byte-slice methods, like Val without json_v2.go.
+type bytesVal[T any] struct {
+	v Val[T]
+}
+
+func (b bytesVal[T]) MarshalJSON() ([]byte, error)    { return b.v.MarshalJSON() }
+func (b *bytesVal[T]) UnmarshalJSON(buf []byte) error { return b.v.UnmarshalJSON(buf) }
+
+type benchRow struct {
+	A Val[int]    `json:"a"`
+	B Val[string] `json:"b"`
+	C Val[int]    `json:"c"`
+}
+
+type benchBytesRow struct {
+	A bytesVal[int]    `json:"a"`
+	B bytesVal[string] `json:"b"`
+	C bytesVal[int]    `json:"c"`
+}
+
+const benchSize = 10000
+
+func benchRows() []benchRow {
+	rows := make([]benchRow, benchSize)
+	for i := range rows {
+		rows[i] = benchRow{A: New(i), B: New("value"), C: Empty[int]()}
+	}
+
+	return rows
+}
+
+func benchBytesRows() []benchBytesRow {
+	rows := make([]benchBytesRow, benchSize)
+	for i := range rows {
+		rows[i] = benchBytesRow{
+			A: bytesVal[int]{New(i)},
+			B: bytesVal[string]{New("value")},
+			C: bytesVal[int]{Empty[int]()},
+		}
+	}
+
+	return rows
+}
+
+func BenchmarkJSONv2Marshal(b *testing.B) {
+	b.Run("streaming", func(b *testing.B) {
+		rows := benchRows()
+		b.ReportAllocs()
+
+		for b.Loop() {
+			if _, err := jsonv2Marshal(rows); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("bytes", func(b *testing.B) {
+		rows := benchBytesRows()
+		b.ReportAllocs()
+
+		for b.Loop() {
+			if _, err := jsonv2Marshal(rows); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkJSONv2Unmarshal(b *testing.B) {
+	b.Run("streaming", func(b *testing.B) {
+		data, err := jsonv2Marshal(benchRows())
+		require.NoError(b, err)
+		b.ReportAllocs()
+
+		for b.Loop() {
+			var rows []benchRow
+			if err := jsonv2Unmarshal(data, &rows); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("bytes", func(b *testing.B) {
+		data, err := jsonv2Marshal(benchBytesRows())
+		require.NoError(b, err)
+		b.ReportAllocs()
+
+		for b.Loop() {
+			var rows []benchBytesRow
+			if err := jsonv2Unmarshal(data, &rows); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
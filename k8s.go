@@ -0,0 +1,252 @@
+package optional
+
+import (
+	"reflect"
+	"time"
+)
+
+// deepCopierInto is implemented by pointers to types with deepcopy-gen
+// functions.
+type deepCopierInto[T any] interface {
+	DeepCopyInto(out *T)
+}
+
+// deepCopier is implemented by value types like resource.Quantity.
+type deepCopier[T any] interface {
+	DeepCopy() T
+}
+
+// openAPISchemaTyper is the same as the convention used by openapi-gen
+// from k8s.io/kube-openapi.
+type openAPISchemaTyper interface {
+	OpenAPISchemaType() []string
+}
+
+// openAPISchemaFormatter is the same as the convention used by openapi-gen
+// from k8s.io/kube-openapi.
+type openAPISchemaFormatter interface {
+	OpenAPISchemaFormat() string
+}
+
+// DeepCopyInto copies the receiver into out. Inner value is copied with its
+// own DeepCopyInto or DeepCopy methods when they exist, otherwise slices,
+// maps and pointers are copied recursively. Structs without these methods
+// are copied only when they consist of scalars, like time.Time, and
+// DeepCopyInto panics for other structs and non-nil interfaces, as
+// deepcopy-gen rejects such fields. This method is used by deepcopy-gen and
+// controller-gen for fields of Kubernetes API types.
+func (v *Val[T]) DeepCopyInto(out *Val[T]) {
+	out.hasVal = v.hasVal
+	if !v.hasVal {
+		var zero T
+		out.value = zero
+
+		return
+	}
+
+	switch copier := any(&v.value).(type) {
+	case deepCopierInto[T]:
+		copier.DeepCopyInto(&out.value)
+	case deepCopier[T]:
+		out.value = copier.DeepCopy()
+	default:
+		out.value = deepCopy(reflect.ValueOf(&v.value).Elem()).Interface().(T) //nolint:forcetypeassert
+	}
+}
+
+// DeepCopy returns a deep copy of the receiver. See DeepCopyInto.
+func (v *Val[T]) DeepCopy() *Val[T] {
+	if v == nil {
+		return nil
+	}
+
+	out := new(Val[T])
+	v.DeepCopyInto(out)
+
+	return out
+}
+
+// OpenAPISchemaType reports the OpenAPI type of scalar inner value. It is
+// used by openapi-gen for Kubernetes API types instead of the schema of Val
+// struct. openapi-gen takes only type and format from these methods, so
+// properties of structs and items of slices and maps would be lost. For
+// such T the type is nil and the schema must be described manually.
+func (Val[T]) OpenAPISchemaType() []string {
+	if typer, ok := any(new(T)).(openAPISchemaTyper); ok {
+		return typer.OpenAPISchemaType()
+	}
+
+	typ, _ := openAPIType(reflect.TypeFor[T]())
+	if typ == "" {
+		return nil
+	}
+
+	return []string{typ}
+}
+
+// OpenAPISchemaFormat reports the OpenAPI format of inner value. See
+// OpenAPISchemaType.
+func (Val[T]) OpenAPISchemaFormat() string {
+	if formatter, ok := any(new(T)).(openAPISchemaFormatter); ok {
+		return formatter.OpenAPISchemaFormat()
+	}
+
+	_, format := openAPIType(reflect.TypeFor[T]())
+
+	return format
+}
+
+// DeepCopyInto copies the receiver into out. See Val.DeepCopyInto.
+func (v *Quoted[T]) DeepCopyInto(out *Quoted[T]) {
+	*out = *v
+}
+
+// DeepCopy returns a copy of the receiver.
+func (v *Quoted[T]) DeepCopy() *Quoted[T] {
+	if v == nil {
+		return nil
+	}
+
+	out := new(Quoted[T])
+	v.DeepCopyInto(out)
+
+	return out
+}
+
+// OpenAPISchemaType reports the OpenAPI type of Quoted, which is always
+// string.
+func (Quoted[T]) OpenAPISchemaType() []string {
+	return []string{"string"}
+}
+
+// OpenAPISchemaFormat reports the OpenAPI format of Quoted.
+func (Quoted[T]) OpenAPISchemaFormat() string {
+	return ""
+}
+
+// openAPIType returns type and format of scalar t using the same names as
+// openapi-gen.
+func openAPIType(t reflect.Type) (string, string) {
+	switch t {
+	case reflect.TypeFor[time.Time]():
+		return "string", "date-time"
+	}
+
+	switch t.Kind() { //nolint:exhaustive
+	case reflect.Bool:
+		return "boolean", ""
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint8, reflect.Uint16:
+		return "integer", "int32"
+	case reflect.Int, reflect.Int64, reflect.Uint, reflect.Uint32, reflect.Uint64:
+		return "integer", "int64"
+	case reflect.Float32:
+		return "number", "float"
+	case reflect.Float64:
+		return "number", "double"
+	case reflect.String:
+		return "string", ""
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return "string", "byte"
+		}
+	case reflect.Pointer:
+		return openAPIType(t.Elem())
+	}
+
+	return "", ""
+}
+
+// deepCopy returns a copy of val where slices, maps and pointers are copied
+// recursively. It panics for values which can not be copied without their
+// own DeepCopyInto method.
+func deepCopy(val reflect.Value) reflect.Value {
+	out := reflect.New(val.Type()).Elem()
+
+	switch val.Kind() { //nolint:exhaustive
+	case reflect.Pointer:
+		if !val.IsNil() {
+			out.Set(reflect.New(val.Type().Elem()))
+			out.Elem().Set(deepCopyElem(val.Elem()))
+		}
+	case reflect.Slice:
+		if !val.IsNil() {
+			out.Set(reflect.MakeSlice(val.Type(), val.Len(), val.Len()))
+			for i := range val.Len() {
+				out.Index(i).Set(deepCopyElem(val.Index(i)))
+			}
+		}
+	case reflect.Array:
+		for i := range val.Len() {
+			out.Index(i).Set(deepCopyElem(val.Index(i)))
+		}
+	case reflect.Map:
+		if !val.IsNil() {
+			out.Set(reflect.MakeMapWithSize(val.Type(), val.Len()))
+			iter := val.MapRange()
+			for iter.Next() {
+				out.SetMapIndex(iter.Key(), deepCopyElem(iter.Value()))
+			}
+		}
+	case reflect.Struct:
+		if !isScalar(val.Type()) {
+			panic("optional: can not deep copy " + val.Type().String() + " without DeepCopyInto or DeepCopy method")
+		}
+
+		out.Set(val)
+	case reflect.Interface, reflect.Chan, reflect.Func, reflect.UnsafePointer:
+		if !val.IsNil() {
+			panic("optional: can not deep copy non-nil " + val.Type().String())
+		}
+	default:
+		out.Set(val)
+	}
+
+	return out
+}
+
+// isScalar reports whether values of t do not share memory with their
+// copies. time.Time is scalar, it refers only to immutable locations.
+func isScalar(t reflect.Type) bool {
+	if t == reflect.TypeFor[time.Time]() {
+		return true
+	}
+
+	switch t.Kind() { //nolint:exhaustive
+	case reflect.Struct:
+		for i := range t.NumField() {
+			if !isScalar(t.Field(i).Type) {
+				return false
+			}
+		}
+
+		return true
+	case reflect.Array:
+		return isScalar(t.Elem())
+	case reflect.Pointer, reflect.Slice, reflect.Map, reflect.Interface, reflect.Chan, reflect.Func, reflect.UnsafePointer:
+		return false
+	default:
+		return true
+	}
+}
+
+// deepCopyElem copies val with its DeepCopyInto or DeepCopy methods when
+// they exist, otherwise with deepCopy.
+func deepCopyElem(val reflect.Value) reflect.Value {
+	src := reflect.New(val.Type())
+	src.Elem().Set(val)
+
+	method := src.MethodByName("DeepCopyInto")
+	if method.IsValid() && method.Type().NumIn() == 1 && method.Type().In(0) == src.Type() {
+		out := reflect.New(val.Type())
+		method.Call([]reflect.Value{out})
+
+		return out.Elem()
+	}
+
+	method = val.MethodByName("DeepCopy")
+	if method.IsValid() && method.Type().NumIn() == 0 && method.Type().NumOut() == 1 && method.Type().Out(0) == val.Type() {
+		return method.Call(nil)[0]
+	}
+
+	return deepCopy(val)
+}
@@ -0,0 +1,210 @@
+package optional
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type k8sSpec struct {
+	Labels map[string]string
+}
+
+func (in *k8sSpec) DeepCopyInto(out *k8sSpec) {
+	*out = *in
+	out.Labels = make(map[string]string, len(in.Labels))
+	for k, v := range in.Labels {
+		out.Labels[k] = v
+	}
+}
+
+type k8sQuantity struct {
+	value *int
+}
+
+func (q k8sQuantity) DeepCopy() k8sQuantity {
+	val := *q.value
+
+	return k8sQuantity{value: &val}
+}
+
+type k8sIntOrString struct{}
+
+func (k8sIntOrString) OpenAPISchemaType() []string { return []string{"string"} }
+func (k8sIntOrString) OpenAPISchemaFormat() string { return "int-or-string" }
+
+func TestVal_DeepCopyInto(t *testing.T) {
+	t.Parallel()
+
+	t.Run("deep_copy_into", func(t *testing.T) {
+		t.Parallel()
+
+		src := New(k8sSpec{Labels: map[string]string{"a": "1"}})
+		dst := src.DeepCopy()
+		dst.value.Labels["a"] = "2"
+
+		require.True(t, dst.HasVal())
+		assert.Equal(t, "1", src.value.Labels["a"])
+	})
+
+	t.Run("deep_copy", func(t *testing.T) {
+		t.Parallel()
+
+		num := 1
+		src := New(k8sQuantity{value: &num})
+		dst := src.DeepCopy()
+		*dst.value.value = 2
+
+		assert.Equal(t, 1, num)
+	})
+
+	t.Run("reflect", func(t *testing.T) {
+		t.Parallel()
+
+		src := New(map[string][]*k8sSpec{"a": {{Labels: map[string]string{"a": "1"}}, nil}})
+		dst := src.DeepCopy()
+		dst.value["a"][0].Labels["a"] = "2"
+		dst.value["a"] = append(dst.value["a"], nil)
+
+		assert.Equal(t, "1", src.value["a"][0].Labels["a"])
+		assert.Len(t, src.value["a"], 2)
+		assert.Nil(t, dst.value["a"][1])
+	})
+
+	t.Run("slice", func(t *testing.T) {
+		t.Parallel()
+
+		src := New([]string{"a", "b"})
+		dst := src.DeepCopy()
+		dst.value[0] = "c"
+
+		assert.Equal(t, []string{"a", "b"}, src.value)
+		assert.Equal(t, []string{"c", "b"}, dst.value)
+	})
+
+	t.Run("nested_deep_copy", func(t *testing.T) {
+		t.Parallel()
+
+		num := 1
+		src := New([]k8sQuantity{{value: &num}})
+		dst := src.DeepCopy()
+		*dst.value[0].value = 2
+
+		assert.Equal(t, 1, num)
+	})
+
+	t.Run("scalar_struct", func(t *testing.T) {
+		t.Parallel()
+
+		type point struct {
+			X, Y int
+			At   time.Time
+			Tags [2]string
+		}
+
+		src := New([]point{{X: 1, Y: 2, At: time.Unix(1, 0), Tags: [2]string{"a", "b"}}})
+		dst := src.DeepCopy()
+		dst.value[0].Tags[0] = "c"
+
+		assert.Equal(t, "a", src.value[0].Tags[0])
+		assert.Equal(t, src.value[0].At, dst.value[0].At)
+	})
+
+	t.Run("struct_without_deep_copy", func(t *testing.T) {
+		t.Parallel()
+
+		type spec struct {
+			Labels map[string]string
+		}
+
+		src := New(spec{Labels: map[string]string{"a": "1"}})
+		assert.Panics(t, func() { src.DeepCopy() })
+
+		src = New(spec{Labels: nil})
+		assert.Panics(t, func() { src.DeepCopy() })
+
+		// Empty values are copied.
+		empty := Empty[spec]()
+		assert.Equal(t, &empty, empty.DeepCopy())
+	})
+
+	t.Run("interface", func(t *testing.T) {
+		t.Parallel()
+
+		src := New([]any{nil})
+		assert.Equal(t, &src, src.DeepCopy())
+
+		src = New([]any{1})
+		assert.Panics(t, func() { src.DeepCopy() })
+	})
+
+	t.Run("nested", func(t *testing.T) {
+		t.Parallel()
+
+		src := New(New([]int{1}))
+		dst := src.DeepCopy()
+		dst.value.value[0] = 2
+
+		assert.Equal(t, []int{1}, src.value.value)
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		t.Parallel()
+
+		dst := New([]int{1})
+		src := Empty[[]int]()
+		src.DeepCopyInto(&dst)
+
+		assert.False(t, dst.HasVal())
+		assert.Nil(t, dst.value)
+	})
+
+	t.Run("nil", func(t *testing.T) {
+		t.Parallel()
+
+		var src *Val[int]
+		assert.Nil(t, src.DeepCopy())
+	})
+
+	t.Run("quoted", func(t *testing.T) {
+		t.Parallel()
+
+		src := NewQuoted(42)
+		assert.Equal(t, &src, src.DeepCopy())
+	})
+}
+
+func TestVal_OpenAPISchema(t *testing.T) {
+	t.Parallel()
+
+	check := func(t *testing.T, types []string, format string, val interface {
+		OpenAPISchemaType() []string
+		OpenAPISchemaFormat() string
+	},
+	) {
+		t.Helper()
+
+		assert.Equal(t, types, val.OpenAPISchemaType())
+		assert.Equal(t, format, val.OpenAPISchemaFormat())
+	}
+
+	check(t, []string{"boolean"}, "", Val[bool]{})
+	check(t, []string{"integer"}, "int32", Val[int32]{})
+	check(t, []string{"integer"}, "int64", Val[int]{})
+	check(t, []string{"number"}, "double", Val[float64]{})
+	check(t, []string{"number"}, "float", Val[float32]{})
+	check(t, []string{"string"}, "", Val[string]{})
+	check(t, []string{"string"}, "date-time", Val[time.Time]{})
+	check(t, []string{"string"}, "byte", Val[[]byte]{})
+	check(t, []string{"integer"}, "int64", Val[time.Duration]{})
+	check(t, nil, "", Val[[]string]{})
+	check(t, nil, "", Val[[2]int]{})
+	check(t, nil, "", Val[map[string]int]{})
+	check(t, nil, "", Val[k8sSpec]{})
+	check(t, []string{"string"}, "int-or-string", Val[k8sIntOrString]{})
+	check(t, []string{"integer"}, "int64", Val[*int64]{})
+	check(t, nil, "", Val[chan int]{})
+	check(t, []string{"string"}, "", Quoted[int]{})
+}
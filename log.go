@@ -0,0 +1,60 @@
+package optional
+
+import (
+	"log/slog"
+	"sync/atomic"
+)
+
+// LogOptions controls how empty values are logged.
+type LogOptions struct {
+	// Empty is logged instead of empty values. Zero slog.Value is logged as null.
+	Empty slog.Value
+	// DropEmpty drops attributes with empty values. LogValue returns an empty
+	// group in this case, which slog handlers omit.
+	DropEmpty bool
+}
+
+var logOptions atomic.Pointer[LogOptions]
+
+// SetLogOptions sets options which are used by LogValue of all Val values and
+// by logger adapters.
+func SetLogOptions(opts LogOptions) {
+	logOptions.Store(&opts)
+}
+
+// GetLogOptions returns options which are used by LogValue.
+func GetLogOptions() LogOptions {
+	if opts := logOptions.Load(); opts != nil {
+		return *opts
+	}
+
+	return LogOptions{
+		Empty:     slog.Value{},
+		DropEmpty: false,
+	}
+}
+
+// LogValue implements slog.LogValuer. Present value is logged as T, with its
+// own LogValue when it exists. Empty value is logged according to LogOptions.
+func (v Val[T]) LogValue() slog.Value {
+	if !v.hasVal {
+		opts := GetLogOptions()
+		if opts.DropEmpty {
+			return slog.GroupValue()
+		}
+
+		return opts.Empty
+	}
+
+	// Address of value is used to call LogValue of T with pointer receiver.
+	if valuer, ok := any(&v.value).(slog.LogValuer); ok {
+		return valuer.LogValue()
+	}
+
+	return slog.AnyValue(v.value)
+}
+
+// LogValue implements slog.LogValuer. See Val.LogValue.
+func (v Quoted[T]) LogValue() slog.Value {
+	return v.val.LogValue()
+}
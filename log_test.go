@@ -0,0 +1,81 @@
+package optional
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type secret string
+
+func (s *secret) LogValue() slog.Value {
+	return slog.StringValue("***")
+}
+
+func logJSON(attrs ...any) string {
+	var buf bytes.Buffer
+
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{ //nolint:exhaustruct
+		ReplaceAttr: func(groups []string, attr slog.Attr) slog.Attr {
+			if len(groups) == 0 && (attr.Key == slog.TimeKey || attr.Key == slog.LevelKey) {
+				return slog.Attr{}
+			}
+
+			return attr
+		},
+	}))
+	logger.Info("msg", attrs...)
+
+	return buf.String()
+}
+
+func TestLogValue(t *testing.T) {
+	t.Parallel()
+
+	type user struct {
+		Name string `json:"name"`
+	}
+
+	table := []struct {
+		name string
+		val  slog.LogValuer
+		exp  slog.Value
+	}{
+		{name: "int", val: New(42), exp: slog.Int64Value(42)},
+		{name: "string", val: New("hi"), exp: slog.StringValue("hi")},
+		{name: "zero", val: New(""), exp: slog.StringValue("")},
+		{name: "empty", val: Empty[int](), exp: slog.Value{}},
+		{name: "valuer", val: New(secret("pwd")), exp: slog.StringValue("***")},
+		{name: "struct", val: New(user{Name: "kaz"}), exp: slog.AnyValue(user{Name: "kaz"})},
+		{name: "nested", val: New(New(1)), exp: slog.Int64Value(1)},
+		{name: "quoted", val: NewQuoted(42), exp: slog.Int64Value(42)},
+	}
+
+	for _, row := range table {
+		t.Run(row.name, func(t *testing.T) {
+			t.Parallel()
+
+			res := row.val.LogValue().Resolve()
+			assert.True(t, row.exp.Equal(res), "%v != %v", row.exp, res)
+		})
+	}
+}
+
+func TestLogValue_Handler(t *testing.T) {
+	t.Parallel()
+
+	assert.JSONEq(t, `{"msg":"msg","age":42,"name":null,"pwd":"***"}`,
+		logJSON("age", New(42), "name", Empty[string](), "pwd", New(secret("x"))))
+}
+
+func TestLogOptions(t *testing.T) { //nolint:paralleltest
+	defer SetLogOptions(GetLogOptions())
+
+	SetLogOptions(LogOptions{Empty: slog.StringValue("<empty>"), DropEmpty: false})
+	assert.JSONEq(t, `{"msg":"msg","age":42,"name":"<empty>"}`, logJSON("age", New(42), "name", Empty[string]()))
+
+	SetLogOptions(LogOptions{Empty: slog.Value{}, DropEmpty: true})
+	assert.JSONEq(t, `{"msg":"msg","age":42}`, logJSON("age", New(42), "name", Empty[string]()))
+}
@@ -0,0 +1,279 @@
+// Package openapi connects optional.Val with OpenAPI documents.
+//
+// Generation of Go code with oapi-codegen: Annotate marks non-required and
+// nullable scalar properties with oapi-codegen extensions, so they are
+// generated as optional.Val[T] instead of *T:
+//
+//	nickname:
+//	  type: string
+//	  x-go-type: optional.Val[string]
+//	  x-go-type-import:
+//	    path: github.com/kazhuravlev/optional
+//	  x-go-type-skip-optional-pointer: true
+//
+// Run it before oapi-codegen, for example with go:generate:
+//
+//	//go:generate go run github.com/kazhuravlev/optional/cmd/optional-oapi -in api.yaml -out api.annotated.yaml
+//	//go:generate go run github.com/oapi-codegen/oapi-codegen/v2/cmd/oapi-codegen -config oapi-codegen.yaml api.annotated.yaml
+//
+// Generation of OpenAPI 3.1 schemas from Go types: Components returns schemas
+// where Val[T] is the schema of T with null allowed and not required.
+// optional-oapi writes the same schemas for structs of packages:
+//
+//	optional-oapi -out components.yaml ./api/...
+package openapi
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+
+	"github.com/kazhuravlev/optional/schema"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// ImportPath is the import path of optional package.
+	ImportPath = "github.com/kazhuravlev/optional"
+	// RefPrefix is the prefix of references to component schemas.
+	RefPrefix = "#/components/schemas/"
+)
+
+// ErrInvalidDocument returned when the document is not an OpenAPI document.
+var ErrInvalidDocument = errors.New("invalid openapi document")
+
+// AnnotateYAML annotates OpenAPI document in YAML or JSON format and returns it in YAML format.
+func AnnotateYAML(in []byte) ([]byte, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(in, &doc); err != nil {
+		return nil, fmt.Errorf("parse document: %w", err)
+	}
+
+	if _, err := Annotate(&doc); err != nil {
+		return nil, err
+	}
+
+	out, err := yaml.Marshal(&doc)
+	if err != nil {
+		return nil, fmt.Errorf("encode document: %w", err)
+	}
+
+	return out, nil
+}
+
+// Annotate adds x-go-type extensions to properties of component schemas
+// which are not required or nullable. Only properties of scalar types are
+// annotated: string, integer, number and boolean without format or with
+// int32, int64, float and double formats. Properties which already have
+// x-go-type are kept as is. It returns the number of annotated properties.
+func Annotate(doc *yaml.Node) (int, error) {
+	root := doc
+	if root.Kind == yaml.DocumentNode {
+		if len(root.Content) != 1 {
+			return 0, ErrInvalidDocument
+		}
+
+		root = root.Content[0]
+	}
+
+	if root.Kind != yaml.MappingNode {
+		return 0, ErrInvalidDocument
+	}
+
+	schemas := get(get(root, "components"), "schemas")
+	if schemas == nil {
+		return 0, nil
+	}
+
+	if schemas.Kind != yaml.MappingNode {
+		return 0, fmt.Errorf("%w: components.schemas must be an object", ErrInvalidDocument)
+	}
+
+	var count int
+	for i := 1; i < len(schemas.Content); i += 2 {
+		count += annotateSchema(schemas.Content[i])
+	}
+
+	return count, nil
+}
+
+// Components returns OpenAPI 3.1 schemas of given values and all named
+// structs used by them. Keys are names of go types.
+func Components(values ...any) map[string]*schema.Schema {
+	gen := schema.NewGenerator()
+	for _, val := range values {
+		gen.SchemaWithRefPrefix(reflect.TypeOf(val), RefPrefix)
+	}
+
+	return gen.Defs()
+}
+
+// Schema returns OpenAPI 3.1 schema of t. Named structs are referenced as
+// #/components/schemas/Name and are collected into gen.
+func Schema(gen *schema.Generator, t reflect.Type) *schema.Schema {
+	return gen.SchemaWithRefPrefix(t, RefPrefix)
+}
+
+func annotateSchema(node *yaml.Node) int {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return 0
+	}
+
+	var count int
+
+	if props := get(node, "properties"); props != nil && props.Kind == yaml.MappingNode {
+		required := stringList(get(node, "required"))
+		for i := 0; i+1 < len(props.Content); i += 2 {
+			name, prop := props.Content[i].Value, props.Content[i+1]
+			if annotateProperty(prop, !slices.Contains(required, name)) {
+				count++
+			}
+
+			count += annotateSchema(prop)
+		}
+	}
+
+	for _, key := range []string{"items", "additionalProperties"} {
+		count += annotateSchema(get(node, key))
+	}
+
+	for _, key := range []string{"allOf", "oneOf", "anyOf"} {
+		if list := get(node, key); list != nil && list.Kind == yaml.SequenceNode {
+			for _, item := range list.Content {
+				count += annotateSchema(item)
+			}
+		}
+	}
+
+	return count
+}
+
+func annotateProperty(prop *yaml.Node, optional bool) bool {
+	if prop.Kind != yaml.MappingNode || get(prop, "x-go-type") != nil || get(prop, "$ref") != nil || get(prop, "enum") != nil {
+		return false
+	}
+
+	typ, nullable := schemaType(prop)
+	if !optional && !nullable {
+		return false
+	}
+
+	goType, ok := goTypeOf(typ, scalar(get(prop, "format")))
+	if !ok {
+		return false
+	}
+
+	prop.Content = append(prop.Content,
+		str("x-go-type"), str("optional.Val["+goType+"]"),
+		str("x-go-type-import"), &yaml.Node{
+			Kind:    yaml.MappingNode,
+			Tag:     "!!map",
+			Content: []*yaml.Node{str("path"), str(ImportPath)},
+		},
+		str("x-go-type-skip-optional-pointer"), &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!bool", Value: "true"},
+	)
+
+	return true
+}
+
+// schemaType returns non-null type of schema. Both OpenAPI 3.0 (nullable: true)
+// and OpenAPI 3.1 (type: [string, "null"]) styles are supported.
+func schemaType(prop *yaml.Node) (string, bool) {
+	nullable := scalar(get(prop, "nullable")) == "true"
+
+	typeNode := get(prop, "type")
+	if typeNode == nil {
+		return "", nullable
+	}
+
+	if typeNode.Kind == yaml.ScalarNode {
+		return typeNode.Value, nullable
+	}
+
+	var res []string
+	for _, typ := range stringList(typeNode) {
+		if typ == "null" {
+			nullable = true
+
+			continue
+		}
+
+		res = append(res, typ)
+	}
+
+	if len(res) != 1 {
+		return "", nullable
+	}
+
+	return res[0], nullable
+}
+
+// goTypeOf returns the same go types as oapi-codegen does by default.
+func goTypeOf(typ, format string) (string, bool) {
+	switch typ {
+	case "string":
+		if format == "" {
+			return "string", true
+		}
+	case "boolean":
+		if format == "" {
+			return "bool", true
+		}
+	case "integer":
+		switch format {
+		case "":
+			return "int", true
+		case "int32", "int64":
+			return format, true
+		}
+	case "number":
+		switch format {
+		case "", "float":
+			return "float32", true
+		case "double":
+			return "float64", true
+		}
+	}
+
+	return "", false
+}
+
+func get(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+
+	return nil
+}
+
+func scalar(node *yaml.Node) string {
+	if node == nil || node.Kind != yaml.ScalarNode {
+		return ""
+	}
+
+	return node.Value
+}
+
+func stringList(node *yaml.Node) []string {
+	if node == nil || node.Kind != yaml.SequenceNode {
+		return nil
+	}
+
+	res := make([]string, 0, len(node.Content))
+	for _, item := range node.Content {
+		res = append(res, scalar(item))
+	}
+
+	return res
+}
+
+func str(val string) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: val}
+}
@@ -0,0 +1,213 @@
+package openapi
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/kazhuravlev/optional"
+	"github.com/kazhuravlev/optional/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+const spec = `openapi: 3.0.3
+info:
+  title: test
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    User:
+      type: object
+      required: [id, name, bio]
+      properties:
+        id:
+          type: integer
+          format: int64
+        name:
+          type: string
+        bio:
+          type: string
+          nullable: true
+        age:
+          type: integer
+        score:
+          type: number
+          format: double
+        active:
+          type: boolean
+        created_at:
+          type: string
+          format: date-time
+        role:
+          type: string
+          enum: [admin, user]
+        address:
+          $ref: '#/components/schemas/Address'
+        custom:
+          type: string
+          x-go-type: MyString
+        tags:
+          type: array
+          items:
+            type: object
+            properties:
+              label:
+                type: string
+    Address:
+      type: [object, "null"]
+      properties:
+        city:
+          type: [string, "null"]
+`
+
+type prop struct {
+	XGoType       string            `yaml:"x-go-type"`
+	XGoTypeImport map[string]string `yaml:"x-go-type-import"`
+	SkipPointer   bool              `yaml:"x-go-type-skip-optional-pointer"`
+}
+
+type doc struct {
+	Components struct {
+		Schemas map[string]struct {
+			Properties map[string]struct {
+				prop  `yaml:",inline"`
+				Items struct {
+					Properties map[string]prop `yaml:"properties"`
+				} `yaml:"items"`
+			} `yaml:"properties"`
+		} `yaml:"schemas"`
+	} `yaml:"components"`
+}
+
+func TestAnnotate(t *testing.T) {
+	t.Parallel()
+
+	var node yaml.Node
+	require.NoError(t, yaml.Unmarshal([]byte(spec), &node))
+
+	count, err := Annotate(&node)
+	require.NoError(t, err)
+	assert.Equal(t, 6, count)
+
+	var res doc
+	require.NoError(t, node.Decode(&res))
+
+	user := res.Components.Schemas["User"].Properties
+	expected := map[string]string{
+		"id":         "",
+		"name":       "",
+		"bio":        "optional.Val[string]",
+		"age":        "optional.Val[int]",
+		"score":      "optional.Val[float64]",
+		"active":     "optional.Val[bool]",
+		"created_at": "",
+		"role":       "",
+		"address":    "",
+		"custom":     "MyString",
+		"tags":       "",
+	}
+
+	for name, goType := range expected {
+		assert.Equal(t, goType, user[name].XGoType, name)
+		if goType != "" && goType != "MyString" {
+			assert.Equal(t, map[string]string{"path": ImportPath}, user[name].XGoTypeImport, name)
+			assert.True(t, user[name].SkipPointer, name)
+		}
+	}
+
+	assert.Equal(t, "optional.Val[string]", user["tags"].Items.Properties["label"].XGoType)
+	assert.Equal(t, "optional.Val[string]", res.Components.Schemas["Address"].Properties["city"].XGoType)
+}
+
+func TestAnnotateYAML(t *testing.T) {
+	t.Parallel()
+
+	t.Run("json_input", func(t *testing.T) {
+		t.Parallel()
+
+		in := `{"openapi":"3.1.0","components":{"schemas":{"A":{"type":"object","properties":{"b":{"type":"boolean"}}}}}}`
+		out, err := AnnotateYAML([]byte(in))
+		require.NoError(t, err)
+
+		var res doc
+		require.NoError(t, yaml.Unmarshal(out, &res))
+		assert.Equal(t, "optional.Val[bool]", res.Components.Schemas["A"].Properties["b"].XGoType)
+	})
+
+	t.Run("idempotent", func(t *testing.T) {
+		t.Parallel()
+
+		first, err := AnnotateYAML([]byte(spec))
+		require.NoError(t, err)
+
+		second, err := AnnotateYAML(first)
+		require.NoError(t, err)
+		assert.Equal(t, string(first), string(second))
+	})
+
+	t.Run("without_components", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := AnnotateYAML([]byte("openapi: 3.1.0\n"))
+		require.NoError(t, err)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := AnnotateYAML([]byte("- a\n- b\n"))
+		require.ErrorIs(t, err, ErrInvalidDocument)
+
+		_, err = AnnotateYAML([]byte("components:\n  schemas: []\n"))
+		require.ErrorIs(t, err, ErrInvalidDocument)
+	})
+}
+
+type Pet struct {
+	Name  string               `json:"name"`
+	Owner optional.Val[Owner]  `json:"owner"`
+	Age   optional.Val[int]    `json:"age"`
+	Tag   optional.Val[string] `json:"tag"`
+}
+
+type Owner struct {
+	Email string `json:"email"`
+}
+
+func TestComponents(t *testing.T) {
+	t.Parallel()
+
+	res, err := json.Marshal(Components(Pet{}))
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `{
+		"Owner": {
+			"type": "object",
+			"properties": {"email": {"type": "string"}},
+			"required": ["email"]
+		},
+		"Pet": {
+			"type": "object",
+			"properties": {
+				"name": {"type": "string"},
+				"owner": {"anyOf": [{"$ref": "#/components/schemas/Owner"}, {"type": "null"}]},
+				"age": {"type": ["integer", "null"]},
+				"tag": {"type": ["string", "null"]}
+			},
+			"required": ["name"]
+		}
+	}`, string(res))
+}
+
+func TestSchema(t *testing.T) {
+	t.Parallel()
+
+	gen := schema.NewGenerator()
+	res, err := json.Marshal(Schema(gen, reflect.TypeFor[[]Pet]()))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"type":"array","items":{"$ref":"#/components/schemas/Pet"}}`, string(res))
+	assert.Contains(t, gen.Defs(), "Owner")
+}
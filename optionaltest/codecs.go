@@ -0,0 +1,156 @@
+package optionaltest
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+
+	"github.com/kazhuravlev/optional"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+// codec encodes the value and decodes the result into a new value.
+type codec[T any] struct {
+	name      string
+	roundTrip func(val optional.Val[T]) (optional.Val[T], error)
+}
+
+// CheckCodecs checks that optional.New(sample) for every sample and
+// optional.Empty[T]() survive a round trip through every codec of Val: JSON,
+// YAML, SQL (Value and Scan), GraphQL and encoding/json/v2 when it is
+// enabled. It reports asymmetries like "empty round-trips as present zero
+// value" or "Value returns a non-driver type".
+//
+// Values are compared with Equal method of T when it exists (for example,
+// time.Time), otherwise with assert.ObjectsAreEqual.
+func CheckCodecs[T any](t assert.TestingT, samples ...T) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	codecs := append([]codec[T]{
+		{name: "json", roundTrip: roundTripJSON[T]},
+		{name: "yaml", roundTrip: roundTripYAML[T]},
+		{name: "sql", roundTrip: roundTripSQL[T]},
+		{name: "graphql", roundTrip: roundTripGQL[T]},
+	}, extraCodecs[T]()...)
+
+	vals := []optional.Val[T]{optional.Empty[T]()}
+	for _, sample := range samples {
+		vals = append(vals, optional.New(sample))
+	}
+
+	ok := true
+
+	for _, codec := range codecs {
+		for _, val := range vals {
+			if problem := checkRoundTrip(codec, val); problem != "" {
+				ok = assert.Fail(t, fmt.Sprintf("%s: %s", codec.name, problem))
+			}
+		}
+	}
+
+	return ok
+}
+
+func checkRoundTrip[T any](codec codec[T], val optional.Val[T]) string {
+	got, err := codec.roundTrip(val)
+
+	switch {
+	case err != nil:
+		return fmt.Sprintf("%s: %v", Describe(val), err)
+	case !val.HasVal() && got.HasVal():
+		return fmt.Sprintf("empty round-trips as %s", Describe(got))
+	case val.HasVal() && !got.HasVal():
+		return fmt.Sprintf("%s round-trips as empty", Describe(val))
+	case val.HasVal() && !equal(val.Val(), got.Val()):
+		return fmt.Sprintf("%s round-trips as %s", Describe(val), Describe(got))
+	}
+
+	return ""
+}
+
+func equal[T any](want, got T) bool {
+	if eq, ok := any(want).(interface{ Equal(other T) bool }); ok {
+		return eq.Equal(got)
+	}
+
+	return assert.ObjectsAreEqual(want, got)
+}
+
+func roundTripJSON[T any](val optional.Val[T]) (optional.Val[T], error) {
+	var res optional.Val[T]
+
+	buf, err := val.MarshalJSON()
+	if err != nil {
+		return res, fmt.Errorf("marshal: %w", err)
+	}
+
+	if err := res.UnmarshalJSON(buf); err != nil {
+		return res, fmt.Errorf("unmarshal %s: %w", buf, err)
+	}
+
+	return res, nil
+}
+
+func roundTripYAML[T any](val optional.Val[T]) (optional.Val[T], error) {
+	type document struct {
+		V optional.Val[T] `yaml:"v"`
+	}
+
+	var res document
+
+	buf, err := yaml.Marshal(document{V: val})
+	if err != nil {
+		return res.V, fmt.Errorf("marshal: %w", err)
+	}
+
+	if err := yaml.Unmarshal(buf, &res); err != nil {
+		return res.V, fmt.Errorf("unmarshal %q: %w", buf, err)
+	}
+
+	return res.V, nil
+}
+
+func roundTripSQL[T any](val optional.Val[T]) (optional.Val[T], error) {
+	var res optional.Val[T]
+
+	value, err := val.Value()
+	if err != nil {
+		return res, fmt.Errorf("value: %w", err)
+	}
+
+	if !driver.IsValue(value) {
+		return res, fmt.Errorf("Value returns a non-driver type %T", value) //nolint:err113
+	}
+
+	if err := res.Scan(value); err != nil {
+		return res, fmt.Errorf("scan %#v: %w", value, err)
+	}
+
+	return res, nil
+}
+
+func roundTripGQL[T any](val optional.Val[T]) (optional.Val[T], error) {
+	var res optional.Val[T]
+
+	var buf bytes.Buffer
+	val.MarshalGQL(&buf)
+
+	// gqlgen decodes input values from JSON with json.Number.
+	dec := json.NewDecoder(&buf)
+	dec.UseNumber()
+
+	var input any
+	if err := dec.Decode(&input); err != nil {
+		return res, fmt.Errorf("decode %s: %w", buf.String(), err)
+	}
+
+	if err := res.UnmarshalGQL(input); err != nil {
+		return res, fmt.Errorf("unmarshal %#v: %w", input, err)
+	}
+
+	return res, nil
+}
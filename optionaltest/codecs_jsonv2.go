@@ -0,0 +1,28 @@
+//go:build goexperiment.jsonv2 && go1.25
+
+package optionaltest
+
+import (
+	"fmt"
+
+	"github.com/kazhuravlev/optional"
+)
+
+func extraCodecs[T any]() []codec[T] {
+	return []codec[T]{{name: "json/v2", roundTrip: roundTripJSONv2[T]}}
+}
+
+func roundTripJSONv2[T any](val optional.Val[T]) (optional.Val[T], error) {
+	var res optional.Val[T]
+
+	buf, err := jsonv2Marshal(val)
+	if err != nil {
+		return res, fmt.Errorf("marshal: %w", err)
+	}
+
+	if err := jsonv2Unmarshal(buf, &res); err != nil {
+		return res, fmt.Errorf("unmarshal %s: %w", buf, err)
+	}
+
+	return res, nil
+}
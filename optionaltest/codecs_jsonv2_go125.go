@@ -0,0 +1,15 @@
+//go:build goexperiment.jsonv2 && go1.25 && !go1.27
+
+package optionaltest
+
+import jsonv2 "encoding/json/v2"
+
+// encoding/json/v2 is experimental in Go 1.25 and 1.26. Since Go 1.27 it is
+// a stable API, and go vet reports its use in files built for older Go. The
+// names below keep codecs_jsonv2.go free of version tags;
+// codecs_jsonv2_go127.go is the same file for Go 1.27+.
+
+var (
+	jsonv2Marshal   = jsonv2.Marshal
+	jsonv2Unmarshal = jsonv2.Unmarshal
+)
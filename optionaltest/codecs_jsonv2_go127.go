@@ -0,0 +1,13 @@
+//go:build goexperiment.jsonv2 && go1.27
+
+package optionaltest
+
+import jsonv2 "encoding/json/v2"
+
+// The same as codecs_jsonv2_go125.go for Go 1.27+, where encoding/json/v2 is
+// stable.
+
+var (
+	jsonv2Marshal   = jsonv2.Marshal
+	jsonv2Unmarshal = jsonv2.Unmarshal
+)
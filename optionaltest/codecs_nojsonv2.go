@@ -0,0 +1,7 @@
+//go:build !goexperiment.jsonv2
+
+package optionaltest
+
+func extraCodecs[T any]() []codec[T] {
+	return nil
+}
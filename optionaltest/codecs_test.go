@@ -0,0 +1,152 @@
+package optionaltest_test
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kazhuravlev/optional/optionaltest"
+	"github.com/stretchr/testify/assert"
+)
+
+type email string
+
+// nullZero encodes zero value as JSON null.
+type nullZero int
+
+func (v nullZero) MarshalJSON() ([]byte, error) {
+	if v == 0 {
+		return []byte("null"), nil
+	}
+
+	return json.Marshal(int(v)) //nolint:wrapcheck
+}
+
+// nilScanner accepts NULL as a regular value.
+type nilScanner struct {
+	V string
+}
+
+func (s *nilScanner) Scan(value any) error {
+	if value == nil {
+		*s = nilScanner{V: ""}
+
+		return nil
+	}
+
+	str, ok := value.(string)
+	if !ok {
+		return errors.New("unexpected type")
+	}
+
+	*s = nilScanner{V: str}
+
+	return nil
+}
+
+func (s nilScanner) Value() (driver.Value, error) {
+	return s.V, nil
+}
+
+// point is not supported by database/sql.
+type point struct {
+	X, Y int
+}
+
+func TestCheckCodecs(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 1, 2, 3, 4, 5, 6, time.UTC)
+
+	table := []struct {
+		name string
+		run  func(t *fakeT) bool
+	}{
+		{name: "string", run: func(t *fakeT) bool { return optionaltest.CheckCodecs(t, "", "hi", "null", "\"") }},
+		{name: "int64", run: func(t *fakeT) bool { return optionaltest.CheckCodecs[int64](t, 0, -1, 42) }},
+		{name: "float64", run: func(t *fakeT) bool { return optionaltest.CheckCodecs(t, 0, 1.5) }},
+		{name: "bool", run: func(t *fakeT) bool { return optionaltest.CheckCodecs(t, false, true) }},
+		{name: "time", run: func(t *fakeT) bool { return optionaltest.CheckCodecs(t, now, time.Time{}) }},
+		{name: "named", run: func(t *fakeT) bool { return optionaltest.CheckCodecs[email](t, "a@b.c") }},
+		{name: "slice", run: func(t *fakeT) bool { return optionaltest.CheckCodecs(t, []string{"a", "b,c"}) }},
+		{name: "no_samples", run: func(t *fakeT) bool { return optionaltest.CheckCodecs[int](t) }},
+	}
+
+	for _, row := range table {
+		t.Run(row.name, func(t *testing.T) {
+			t.Parallel()
+
+			ft := new(fakeT)
+			assert.True(t, row.run(ft))
+			assert.Empty(t, ft.errors)
+		})
+	}
+}
+
+func TestCheckCodecs_Asymmetries(t *testing.T) {
+	t.Parallel()
+
+	table := []struct {
+		name string
+		run  func(t *fakeT) bool
+		exp  []string
+	}{
+		{
+			name: "present_as_empty",
+			run:  func(t *fakeT) bool { return optionaltest.CheckCodecs[nullZero](t, 0, 1) },
+			exp: []string{
+				"json: present: 0 round-trips as empty",
+				"graphql: present: 0 round-trips as empty",
+			},
+		},
+		{
+			name: "empty_as_present",
+			run:  func(t *fakeT) bool { return optionaltest.CheckCodecs(t, nilScanner{V: "a"}) },
+			exp: []string{
+				`sql: empty round-trips as present: optionaltest_test.nilScanner{V:""}`,
+			},
+		},
+		{
+			name: "non_driver_type",
+			run:  func(t *fakeT) bool { return optionaltest.CheckCodecs(t, point{X: 1, Y: 2}) },
+			exp: []string{
+				"sql: present: optionaltest_test.point{X:1, Y:2}: Value returns a non-driver type optionaltest_test.point",
+			},
+		},
+		{
+			name: "nil_slice",
+			run:  func(t *fakeT) bool { return optionaltest.CheckCodecs[[]int](t, nil) },
+			exp: []string{
+				"json: present: []int(nil) round-trips as empty",
+				"yaml: present: []int(nil) round-trips as present: []int{}",
+				"graphql: present: []int(nil) round-trips as empty",
+			},
+		},
+	}
+
+	for _, row := range table {
+		t.Run(row.name, func(t *testing.T) {
+			t.Parallel()
+
+			ft := new(fakeT)
+			assert.False(t, row.run(ft))
+
+			for _, exp := range row.exp {
+				assert.True(t, containsError(ft.errors, exp), "%q not found in %q", exp, ft.errors)
+			}
+		})
+	}
+}
+
+func containsError(errs []string, sub string) bool {
+	for _, err := range errs {
+		if strings.Contains(err, sub) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,77 @@
+package optionaltest
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Matcher matches optional values. It implements gomock.Matcher and
+// gomock.GotFormatter, so it can be used as an argument of EXPECT() calls.
+type Matcher interface {
+	// Matches returns whether x is a match.
+	Matches(x any) bool
+	// String describes what the matcher matches.
+	String() string
+	// Got describes the received value.
+	Got(got any) string
+}
+
+// HasValMatcher returns a matcher for present optional.Val[T] or *optional.Val[T]
+// with a value equal to want.
+func HasValMatcher[T any](want T) Matcher { //nolint:ireturn
+	return hasValMatcher[T]{want: want}
+}
+
+// EmptyMatcher returns a matcher for empty optional values of any type.
+func EmptyMatcher() Matcher { //nolint:ireturn
+	return emptyMatcher{}
+}
+
+type hasValMatcher[T any] struct {
+	want T
+}
+
+func (m hasValMatcher[T]) Matches(x any) bool {
+	val, ok := x.(interface{ Get() (T, bool) })
+	if !ok || isNil(x) {
+		return false
+	}
+
+	if got, ok := val.Get(); ok {
+		return assert.ObjectsAreEqual(m.want, got)
+	}
+
+	return false
+}
+
+func (m hasValMatcher[T]) String() string {
+	return fmt.Sprintf("present: %#v", m.want)
+}
+
+func (m hasValMatcher[T]) Got(got any) string {
+	return Describe(got)
+}
+
+type emptyMatcher struct{}
+
+func (emptyMatcher) Matches(x any) bool {
+	val, ok := x.(interface{ HasVal() bool })
+
+	return ok && !isNil(x) && !val.HasVal()
+}
+
+func (emptyMatcher) String() string {
+	return "empty"
+}
+
+func (emptyMatcher) Got(got any) string {
+	return Describe(got)
+}
+
+func isNil(x any) bool {
+	rv := reflect.ValueOf(x)
+
+	return rv.Kind() == reflect.Pointer && rv.IsNil()
+}
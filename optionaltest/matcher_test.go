@@ -0,0 +1,106 @@
+package optionaltest_test
+
+import (
+	"testing"
+
+	"github.com/kazhuravlev/optional"
+	"github.com/kazhuravlev/optional/optionaltest"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+var (
+	_ gomock.Matcher      = optionaltest.HasValMatcher(0)
+	_ gomock.GotFormatter = optionaltest.HasValMatcher(0)
+	_ gomock.Matcher      = optionaltest.EmptyMatcher()
+	_ gomock.GotFormatter = optionaltest.EmptyMatcher()
+)
+
+func TestHasValMatcher(t *testing.T) {
+	t.Parallel()
+
+	val := optional.New(42)
+
+	table := []struct {
+		name string
+		in   any
+		exp  bool
+	}{
+		{name: "equal", in: optional.New(42), exp: true},
+		{name: "pointer", in: &val, exp: true},
+		{name: "quoted", in: optional.NewQuoted(42), exp: true},
+		{name: "not_equal", in: optional.New(1), exp: false},
+		{name: "empty", in: optional.Empty[int](), exp: false},
+		{name: "nil_pointer", in: (*optional.Val[int])(nil), exp: false},
+		{name: "other_type", in: optional.New[int64](42), exp: false},
+		{name: "raw_value", in: 42, exp: false},
+		{name: "nil", in: nil, exp: false},
+	}
+
+	for _, row := range table {
+		t.Run(row.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, row.exp, optionaltest.HasValMatcher(42).Matches(row.in))
+		})
+	}
+}
+
+func TestEmptyMatcher(t *testing.T) {
+	t.Parallel()
+
+	val := optional.Empty[string]()
+
+	table := []struct {
+		name string
+		in   any
+		exp  bool
+	}{
+		{name: "empty", in: optional.Empty[int](), exp: true},
+		{name: "pointer", in: &val, exp: true},
+		{name: "quoted", in: optional.Quoted[int]{}, exp: true},
+		{name: "present", in: optional.New(0), exp: false},
+		{name: "nil_pointer", in: (*optional.Val[int])(nil), exp: false},
+		{name: "raw_value", in: 0, exp: false},
+		{name: "nil", in: nil, exp: false},
+	}
+
+	for _, row := range table {
+		t.Run(row.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, row.exp, optionaltest.EmptyMatcher().Matches(row.in))
+		})
+	}
+}
+
+func TestMatcher_Messages(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, `present: "kaz"`, optionaltest.HasValMatcher("kaz").String())
+	assert.Equal(t, "empty", optionaltest.HasValMatcher("kaz").Got(optional.Empty[string]()))
+	assert.Equal(t, "empty", optionaltest.EmptyMatcher().String())
+	assert.Equal(t, `present: "kaz"`, optionaltest.EmptyMatcher().Got(optional.New("kaz")))
+}
+
+type recorder struct {
+	ctrl *gomock.Controller
+}
+
+func (r *recorder) Update(val optional.Val[string]) {
+	r.ctrl.T.Helper()
+	r.ctrl.Call(r, "Update", val)
+}
+
+func TestMatcher_Gomock(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	rec := &recorder{ctrl: ctrl}
+
+	ctrl.RecordCall(rec, "Update", optionaltest.HasValMatcher("kaz")).Times(1)
+	ctrl.RecordCall(rec, "Update", optionaltest.EmptyMatcher()).Times(1)
+
+	rec.Update(optional.New("kaz"))
+	rec.Update(optional.Empty[string]())
+}
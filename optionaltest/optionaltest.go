@@ -0,0 +1,122 @@
+// Package optionaltest contains test helpers for optional.Val.
+//
+//	optionaltest.AssertHasVal(t, user.Nickname, "kaz")
+//	optionaltest.RequireEmpty(t, user.Age)
+//
+//	repo.EXPECT().Update(optionaltest.HasValMatcher("kaz")).Return(nil)
+//
+// Failure messages show values as "empty" or "present: <value>" instead of
+// the internal structure of optional.Val.
+package optionaltest
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/kazhuravlev/optional"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type tHelper interface {
+	Helper()
+}
+
+// AssertHasVal asserts that val is present and equal to want.
+func AssertHasVal[T any](t assert.TestingT, val optional.Val[T], want T, msgAndArgs ...any) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	if got, ok := val.Get(); ok && assert.ObjectsAreEqual(want, got) {
+		return true
+	}
+
+	return assert.Fail(t, fmt.Sprintf("Not equal: \n"+
+		"expected: %s\n"+
+		"actual  : %s", Describe(optional.New(want)), Describe(val)), msgAndArgs...)
+}
+
+// AssertEmpty asserts that val is empty.
+func AssertEmpty[T any](t assert.TestingT, val optional.Val[T], msgAndArgs ...any) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	if !val.HasVal() {
+		return true
+	}
+
+	return assert.Fail(t, fmt.Sprintf("Should be empty, but was %s", Describe(val)), msgAndArgs...)
+}
+
+// AssertPresent asserts that val is present regardless of its value.
+func AssertPresent[T any](t assert.TestingT, val optional.Val[T], msgAndArgs ...any) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	if val.HasVal() {
+		return true
+	}
+
+	return assert.Fail(t, "Should be present, but was empty", msgAndArgs...)
+}
+
+// RequireHasVal requires that val is present and equal to want.
+func RequireHasVal[T any](t require.TestingT, val optional.Val[T], want T, msgAndArgs ...any) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	if !AssertHasVal(t, val, want, msgAndArgs...) {
+		t.FailNow()
+	}
+}
+
+// RequireEmpty requires that val is empty.
+func RequireEmpty[T any](t require.TestingT, val optional.Val[T], msgAndArgs ...any) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	if !AssertEmpty(t, val, msgAndArgs...) {
+		t.FailNow()
+	}
+}
+
+// RequirePresent requires that val is present and returns its value.
+func RequirePresent[T any](t require.TestingT, val optional.Val[T], msgAndArgs ...any) T { //nolint:ireturn
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	if !AssertPresent(t, val, msgAndArgs...) {
+		t.FailNow()
+	}
+
+	return val.Val()
+}
+
+// Describe returns "empty" or "present: <value>" for optional values and
+// their pointers. Other values are formatted with %#v.
+func Describe(val any) string {
+	if isNil(val) {
+		return "nil"
+	}
+
+	has, ok := val.(interface{ HasVal() bool })
+	if !ok {
+		return fmt.Sprintf("%#v", val)
+	}
+
+	if !has.HasVal() {
+		return "empty"
+	}
+
+	if method := reflect.ValueOf(val).MethodByName("Val"); method.IsValid() && method.Type().NumIn() == 0 && method.Type().NumOut() == 1 {
+		return fmt.Sprintf("present: %#v", method.Call(nil)[0].Interface())
+	}
+
+	return "present"
+}
@@ -0,0 +1,133 @@
+package optionaltest_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/kazhuravlev/optional"
+	"github.com/kazhuravlev/optional/optionaltest"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeT struct {
+	errors []string
+	failed bool
+}
+
+func (t *fakeT) Errorf(format string, args ...any) {
+	t.errors = append(t.errors, fmt.Sprintf(format, args...))
+}
+
+func (t *fakeT) FailNow() {
+	t.failed = true
+}
+
+func TestAssertHasVal(t *testing.T) {
+	t.Parallel()
+
+	table := []struct {
+		name   string
+		val    optional.Val[int]
+		ok     bool
+		actual string
+	}{
+		{name: "equal", val: optional.New(42), ok: true, actual: ""},
+		{name: "not_equal", val: optional.New(1), ok: false, actual: "actual  : present: 1"},
+		{name: "empty", val: optional.Empty[int](), ok: false, actual: "actual  : empty"},
+	}
+
+	for _, row := range table {
+		t.Run(row.name, func(t *testing.T) {
+			t.Parallel()
+
+			ft := new(fakeT)
+			assert.Equal(t, row.ok, optionaltest.AssertHasVal(ft, row.val, 42, "user %d", 1))
+
+			if row.ok {
+				assert.Empty(t, ft.errors)
+
+				return
+			}
+
+			assert.Len(t, ft.errors, 1)
+			assert.Contains(t, ft.errors[0], "expected: present: 42")
+			assert.Contains(t, ft.errors[0], row.actual)
+			assert.Contains(t, ft.errors[0], "user 1")
+			assert.NotContains(t, ft.errors[0], "hasVal")
+		})
+	}
+}
+
+func TestAssertEmpty(t *testing.T) {
+	t.Parallel()
+
+	ft := new(fakeT)
+	assert.True(t, optionaltest.AssertEmpty(ft, optional.Empty[string]()))
+	assert.Empty(t, ft.errors)
+
+	assert.False(t, optionaltest.AssertEmpty(ft, optional.New("")))
+	assert.Len(t, ft.errors, 1)
+	assert.Contains(t, ft.errors[0], `Should be empty, but was present: ""`)
+}
+
+func TestAssertPresent(t *testing.T) {
+	t.Parallel()
+
+	ft := new(fakeT)
+	assert.True(t, optionaltest.AssertPresent(ft, optional.New(0)))
+	assert.Empty(t, ft.errors)
+
+	assert.False(t, optionaltest.AssertPresent(ft, optional.Empty[int]()))
+	assert.Len(t, ft.errors, 1)
+	assert.Contains(t, ft.errors[0], "Should be present, but was empty")
+}
+
+func TestRequire(t *testing.T) {
+	t.Parallel()
+
+	t.Run("has_val", func(t *testing.T) {
+		t.Parallel()
+
+		ft := new(fakeT)
+		optionaltest.RequireHasVal(ft, optional.New(42), 42)
+		assert.False(t, ft.failed)
+
+		optionaltest.RequireHasVal(ft, optional.Empty[int](), 42)
+		assert.True(t, ft.failed)
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		t.Parallel()
+
+		ft := new(fakeT)
+		optionaltest.RequireEmpty(ft, optional.Empty[int]())
+		assert.False(t, ft.failed)
+
+		optionaltest.RequireEmpty(ft, optional.New(0))
+		assert.True(t, ft.failed)
+	})
+
+	t.Run("present", func(t *testing.T) {
+		t.Parallel()
+
+		ft := new(fakeT)
+		assert.Equal(t, 42, optionaltest.RequirePresent(ft, optional.New(42)))
+		assert.False(t, ft.failed)
+
+		optionaltest.RequirePresent(ft, optional.Empty[int]())
+		assert.True(t, ft.failed)
+	})
+}
+
+func TestDescribe(t *testing.T) {
+	t.Parallel()
+
+	val := optional.New("hi")
+
+	assert.Equal(t, `present: "hi"`, optionaltest.Describe(val))
+	assert.Equal(t, `present: "hi"`, optionaltest.Describe(&val))
+	assert.Equal(t, "present: 42", optionaltest.Describe(optional.NewQuoted(42)))
+	assert.Equal(t, "empty", optionaltest.Describe(optional.Empty[int]()))
+	assert.Equal(t, "nil", optionaltest.Describe((*optional.Val[int])(nil)))
+	assert.Equal(t, "42", optionaltest.Describe(42))
+}
@@ -0,0 +1,150 @@
+package optional
+
+import (
+	"math"
+	"math/rand"
+	"reflect"
+)
+
+// Values of strings, maps and slices are limited by this size like in testing/quick.
+const quickSize = 50
+
+// generator is the same interface as quick.Generator. testing/quick is not
+// imported to avoid registration of its flags in every binary.
+type generator interface {
+	Generate(rand *rand.Rand, size int) reflect.Value
+}
+
+// Generate implements quick.Generator. It returns an empty value in one of four
+// cases. Otherwise, the value is generated by Generate method of T or the same
+// way as quick.Value does. Types which quick.Value does not support, like
+// channels and functions, always produce an empty value.
+func (Val[T]) Generate(rand *rand.Rand, size int) reflect.Value {
+	return reflect.ValueOf(generateVal[T](rand, size))
+}
+
+// Generate implements quick.Generator. See Val.Generate.
+func (Quoted[T]) Generate(rand *rand.Rand, size int) reflect.Value {
+	return reflect.ValueOf(Quote(generateVal[T](rand, size)))
+}
+
+func generateVal[T any](rand *rand.Rand, size int) Val[T] {
+	if rand.Intn(4) == 0 { //nolint:mnd
+		return Empty[T]()
+	}
+
+	val, ok := generateValue(reflect.TypeFor[T](), rand, size)
+	if !ok {
+		return Empty[T]()
+	}
+
+	return New(val.Interface().(T)) //nolint:forcetypeassert
+}
+
+// generateValue is a copy of quick.Value which skips unexported fields.
+func generateValue(typ reflect.Type, rand *rand.Rand, size int) (reflect.Value, bool) { //nolint:cyclop,funlen
+	if gen, ok := reflect.Zero(typ).Interface().(generator); ok {
+		return gen.Generate(rand, size), true
+	}
+
+	size = max(size, 1)
+	val := reflect.New(typ).Elem()
+
+	switch typ.Kind() {
+	case reflect.Bool:
+		val.SetBool(rand.Int()&1 == 0)
+	case reflect.Float32:
+		val.SetFloat(randFloat(rand, math.MaxFloat32))
+	case reflect.Float64:
+		val.SetFloat(randFloat(rand, math.MaxFloat64))
+	case reflect.Complex64:
+		val.SetComplex(complex(randFloat(rand, math.MaxFloat32), randFloat(rand, math.MaxFloat32)))
+	case reflect.Complex128:
+		val.SetComplex(complex(randFloat(rand, math.MaxFloat64), randFloat(rand, math.MaxFloat64)))
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		val.SetInt(int64(rand.Uint64())) //nolint:gosec
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		val.SetUint(rand.Uint64())
+	case reflect.String:
+		runes := make([]rune, rand.Intn(quickSize))
+		for i := range runes {
+			runes[i] = rune(rand.Intn(0x10ffff)) //nolint:mnd
+		}
+
+		val.SetString(string(runes))
+	case reflect.Pointer:
+		if rand.Intn(size) == 0 {
+			return val, true
+		}
+
+		elem, ok := generateValue(typ.Elem(), rand, size)
+		if !ok {
+			return reflect.Value{}, false
+		}
+
+		val.Set(reflect.New(typ.Elem()))
+		val.Elem().Set(elem)
+	case reflect.Slice:
+		n := rand.Intn(size)
+		val.Set(reflect.MakeSlice(typ, n, n))
+
+		for i := range n {
+			elem, ok := generateValue(typ.Elem(), rand, size-n)
+			if !ok {
+				return reflect.Value{}, false
+			}
+
+			val.Index(i).Set(elem)
+		}
+	case reflect.Array:
+		for i := range val.Len() {
+			elem, ok := generateValue(typ.Elem(), rand, size)
+			if !ok {
+				return reflect.Value{}, false
+			}
+
+			val.Index(i).Set(elem)
+		}
+	case reflect.Map:
+		val.Set(reflect.MakeMap(typ))
+
+		for range rand.Intn(size) {
+			key, ok1 := generateValue(typ.Key(), rand, size)
+			elem, ok2 := generateValue(typ.Elem(), rand, size)
+
+			if !ok1 || !ok2 {
+				return reflect.Value{}, false
+			}
+
+			val.SetMapIndex(key, elem)
+		}
+	case reflect.Struct:
+		fieldSize := max(size/max(typ.NumField(), 1), 1)
+
+		for i := range typ.NumField() {
+			if !typ.Field(i).IsExported() {
+				continue
+			}
+
+			elem, ok := generateValue(typ.Field(i).Type, rand, fieldSize)
+			if !ok {
+				return reflect.Value{}, false
+			}
+
+			val.Field(i).Set(elem)
+		}
+	default:
+		return reflect.Value{}, false
+	}
+
+	return val, true
+}
+
+func randFloat(rand *rand.Rand, limit float64) float64 {
+	res := rand.Float64() * limit
+	if rand.Int()&1 == 1 {
+		res = -res
+	}
+
+	return res
+}
@@ -0,0 +1,98 @@
+package optional
+
+import (
+	"encoding/json"
+	"math/rand"
+	"reflect"
+	"testing"
+	"testing/quick"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerate(t *testing.T) {
+	t.Parallel()
+
+	rnd := rand.New(rand.NewSource(1)) //nolint:gosec
+
+	empty := 0
+	for range 1000 {
+		val, ok := quick.Value(reflect.TypeFor[Val[int]](), rnd)
+		require.True(t, ok)
+
+		if !val.Interface().(Val[int]).HasVal() { //nolint:forcetypeassert
+			empty++
+		}
+	}
+
+	assert.InDelta(t, 250, empty, 50)
+}
+
+func TestGenerate_Types(t *testing.T) {
+	t.Parallel()
+
+	type inner struct {
+		Tags   Val[[]string]
+		hidden Val[int]
+	}
+
+	type payload struct {
+		Name   Val[string]
+		Age    Val[int]
+		Inner  Val[inner]
+		Nested Val[Val[bool]]
+		Ptr    Val[*float64]
+		Score  Quoted[float64]
+	}
+
+	rnd := rand.New(rand.NewSource(1)) //nolint:gosec
+
+	seen := make(map[string]bool)
+	for range 100 {
+		val, ok := quick.Value(reflect.TypeFor[payload](), rnd)
+		require.True(t, ok)
+
+		res := val.Interface().(payload) //nolint:forcetypeassert
+		assert.False(t, res.Inner.ValDefault(inner{}).hidden.HasVal(), "unexported fields are skipped")
+
+		seen["name"] = seen["name"] || res.Name.HasVal()
+		seen["inner"] = seen["inner"] || res.Inner.HasVal() && res.Inner.Val().Tags.HasVal()
+		seen["nested"] = seen["nested"] || res.Nested.HasVal() && res.Nested.Val().HasVal()
+		seen["score"] = seen["score"] || res.Score.HasVal()
+	}
+
+	assert.Equal(t, map[string]bool{"name": true, "inner": true, "nested": true, "score": true}, seen)
+}
+
+func TestGenerate_Unsupported(t *testing.T) {
+	t.Parallel()
+
+	rnd := rand.New(rand.NewSource(1)) //nolint:gosec
+
+	for range 100 {
+		val, ok := quick.Value(reflect.TypeFor[Val[chan int]](), rnd)
+		require.True(t, ok)
+		assert.False(t, val.Interface().(Val[chan int]).HasVal()) //nolint:forcetypeassert
+	}
+}
+
+func TestGenerate_Check(t *testing.T) {
+	t.Parallel()
+
+	roundTrip := func(val Val[map[string]int]) bool {
+		buf, err := json.Marshal(val)
+		if err != nil {
+			return false
+		}
+
+		var res Val[map[string]int]
+		if err := json.Unmarshal(buf, &res); err != nil {
+			return false
+		}
+
+		return reflect.DeepEqual(val, res)
+	}
+
+	require.NoError(t, quick.Check(roundTrip, nil))
+}
@@ -0,0 +1,128 @@
+package optional
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// Quotable is a constraint for types which can be stored in Quoted.
+type Quotable interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 |
+		~float32 | ~float64 | ~bool
+}
+
+var errQuotedNull = errors.New("null must not be quoted")
+
+// Quoted is the same as Val, but present values are encoded into JSON as
+// strings like fields with `,string` option. encoding/json ignores this
+// option for types which implement json.Marshaler, so use Quoted instead:
+//
+//	type User struct {
+//		ID optional.Quoted[int64] `json:"id"` // {"id":"42"}
+//	}
+//
+// Both quoted and unquoted values are accepted by UnmarshalJSON.
+type Quoted[T Quotable] struct {
+	val Val[T]
+}
+
+// NewQuoted create Quoted with value.
+func NewQuoted[T Quotable](val T) Quoted[T] {
+	return Quoted[T]{val: New(val)}
+}
+
+// EmptyQuoted create Quoted without value.
+func EmptyQuoted[T Quotable]() Quoted[T] {
+	return Quoted[T]{val: Empty[T]()}
+}
+
+// Quote adapt Val to Quoted.
+func Quote[T Quotable](val Val[T]) Quoted[T] {
+	return Quoted[T]{val: val}
+}
+
+// Unquote adapt Quoted to Val.
+func (v Quoted[T]) Unquote() Val[T] {
+	return v.val
+}
+
+// Get returns the value and the flag of its presence. See Val.Get.
+func (v Quoted[T]) Get() (T, bool) { //nolint:ireturn
+	return v.val.Get()
+}
+
+// Val returns the value or zero value of T. See Val.Val.
+func (v Quoted[T]) Val() T { //nolint:ireturn
+	return v.val.Val()
+}
+
+// ValDefault returns the value or defaultVal. See Val.ValDefault.
+func (v Quoted[T]) ValDefault(defaultVal T) T { //nolint:ireturn
+	return v.val.ValDefault(defaultVal)
+}
+
+// HasVal reports whether the value is present. See Val.HasVal.
+func (v Quoted[T]) HasVal() bool {
+	return v.val.HasVal()
+}
+
+// AsPointer returns a pointer to a copy of the value. See Val.AsPointer.
+func (v Quoted[T]) AsPointer() *T {
+	return v.val.AsPointer()
+}
+
+// Set sets the value. See Val.Set.
+func (v *Quoted[T]) Set(val T) {
+	v.val.Set(val)
+}
+
+// Reset removes the value. See Val.Reset.
+func (v *Quoted[T]) Reset() {
+	v.val.Reset()
+}
+
+// MarshalJSON implements json.Marshaler.
+func (v Quoted[T]) MarshalJSON() ([]byte, error) {
+	if !v.val.hasVal {
+		return []byte("null"), nil
+	}
+
+	res, err := json.Marshal(&v.val.value)
+	if err != nil {
+		return nil, fmt.Errorf("marshal json: %w", err)
+	}
+
+	return append(append([]byte{'"'}, res...), '"'), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (v *Quoted[T]) UnmarshalJSON(buf []byte) error {
+	if len(buf) == 0 || buf[0] != '"' {
+		return v.val.UnmarshalJSON(buf)
+	}
+
+	var str string
+	if err := json.Unmarshal(buf, &str); err != nil {
+		return newDecodeError[T](FormatJSON, err)
+	}
+
+	inner := []byte(str)
+	if bytes.Equal(inner, []byte("null")) {
+		v.val.value, v.val.hasVal = *new(T), false
+
+		return newDecodeError[T](FormatJSON, errQuotedNull)
+	}
+
+	if err := json.Unmarshal(inner, &v.val.value); err != nil {
+		v.val.value, v.val.hasVal = *new(T), false
+
+		return newDecodeError[T](FormatJSON, err)
+	}
+
+	v.val.hasVal = true
+
+	return nil
+}
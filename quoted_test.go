@@ -0,0 +1,134 @@
+package optional
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuotedMarshal(t *testing.T) {
+	t.Parallel()
+
+	type payload struct {
+		ID     Quoted[int64]   `json:"id"`
+		Score  Quoted[float64] `json:"score"`
+		Active Quoted[bool]    `json:"active"`
+	}
+
+	tests := []struct {
+		name string
+		val  payload
+		exp  string
+	}{
+		{
+			name: "with_values",
+			val: payload{
+				ID:     NewQuoted[int64](9007199254740993),
+				Score:  NewQuoted(1.5),
+				Active: NewQuoted(true),
+			},
+			exp: `{"id":"9007199254740993","score":"1.5","active":"true"}`,
+		},
+		{
+			name: "zero_values",
+			val: payload{
+				ID:     NewQuoted[int64](0),
+				Score:  NewQuoted[float64](0),
+				Active: NewQuoted(false),
+			},
+			exp: `{"id":"0","score":"0","active":"false"}`,
+		},
+		{
+			name: "empty",
+			val: payload{
+				ID:     EmptyQuoted[int64](),
+				Score:  EmptyQuoted[float64](),
+				Active: EmptyQuoted[bool](),
+			},
+			exp: `{"id":null,"score":null,"active":null}`,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := json.Marshal(tt.val)
+			require.NoError(t, err)
+			assert.Equal(t, tt.exp, string(res))
+
+			var restored payload
+			require.NoError(t, json.Unmarshal(res, &restored))
+			assert.Equal(t, tt.val, restored)
+		})
+	}
+}
+
+func TestQuotedUnmarshal(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		in     string
+		exp    Quoted[int64]
+		expErr bool
+	}{
+		{name: "quoted", in: `"42"`, exp: NewQuoted[int64](42)},
+		{name: "unquoted", in: `42`, exp: NewQuoted[int64](42)},
+		{name: "quoted_zero", in: `"0"`, exp: NewQuoted[int64](0)},
+		{name: "null", in: `null`, exp: EmptyQuoted[int64]()},
+		{name: "quoted_null", in: `"null"`, exp: EmptyQuoted[int64](), expErr: true},
+		{name: "quoted_empty", in: `""`, exp: EmptyQuoted[int64](), expErr: true},
+		{name: "quoted_text", in: `"abc"`, exp: EmptyQuoted[int64](), expErr: true},
+		{name: "quoted_float", in: `"4.2"`, exp: EmptyQuoted[int64](), expErr: true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			val := NewQuoted[int64](100)
+			err := json.Unmarshal([]byte(tt.in), &val)
+			if tt.expErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			assert.Equal(t, tt.exp, val)
+		})
+	}
+
+	t.Run("bool", func(t *testing.T) {
+		t.Parallel()
+
+		var val Quoted[bool]
+		require.NoError(t, json.Unmarshal([]byte(`"true"`), &val))
+		assert.Equal(t, NewQuoted(true), val)
+
+		require.NoError(t, json.Unmarshal([]byte(`false`), &val))
+		assert.Equal(t, NewQuoted(false), val)
+	})
+}
+
+func TestQuotedVal(t *testing.T) {
+	t.Parallel()
+
+	val := Quote(New[int64](42))
+	assert.True(t, val.HasVal())
+	assert.Equal(t, int64(42), val.Val())
+	assert.Equal(t, New[int64](42), val.Unquote())
+
+	val.Reset()
+	assert.Equal(t, EmptyQuoted[int64](), val)
+	assert.Equal(t, int64(7), val.ValDefault(7))
+
+	val.Set(7)
+	res, ok := val.Get()
+	require.True(t, ok)
+	assert.Equal(t, int64(7), res)
+}
@@ -0,0 +1,35 @@
+// Package rapidopt contains generators of optional values for pgregory.net/rapid.
+//
+//	rapid.Check(t, func(t *rapid.T) {
+//		nickname := rapidopt.Val(rapid.String()).Draw(t, "nickname")
+//		...
+//	})
+//
+// Generated values shrink to empty optionals and then to smaller values.
+package rapidopt
+
+import (
+	"github.com/kazhuravlev/optional"
+	"pgregory.net/rapid"
+)
+
+// Val returns a generator of optional.Val which are either empty or contain a value drawn from gen.
+func Val[T any](gen *rapid.Generator[T]) *rapid.Generator[optional.Val[T]] {
+	return rapid.Custom(func(t *rapid.T) optional.Val[T] {
+		if !rapid.Bool().Draw(t, "present") {
+			return optional.Empty[T]()
+		}
+
+		return optional.New(gen.Draw(t, "value"))
+	})
+}
+
+// Quoted returns a generator of optional.Quoted. See Val.
+func Quoted[T optional.Quotable](gen *rapid.Generator[T]) *rapid.Generator[optional.Quoted[T]] {
+	return rapid.Map(Val(gen), optional.Quote[T])
+}
+
+// Make returns a generator of optional.Val with values from rapid.Make.
+func Make[T any]() *rapid.Generator[optional.Val[T]] {
+	return Val(rapid.Make[T]())
+}
@@ -0,0 +1,68 @@
+package rapidopt_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/kazhuravlev/optional"
+	"github.com/kazhuravlev/optional/rapidopt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"pgregory.net/rapid"
+)
+
+type user struct {
+	Nickname optional.Val[string]   `json:"nickname"`
+	Age      optional.Val[int]      `json:"age"`
+	Tags     optional.Val[[]string] `json:"tags"`
+	Score    optional.Quoted[int64] `json:"score"`
+}
+
+func userGen() *rapid.Generator[user] {
+	return rapid.Custom(func(t *rapid.T) user {
+		return user{
+			Nickname: rapidopt.Val(rapid.String()).Draw(t, "nickname"),
+			Age:      rapidopt.Make[int]().Draw(t, "age"),
+			Tags:     rapidopt.Val(rapid.SliceOfN(rapid.String(), 1, 3)).Draw(t, "tags"),
+			Score:    rapidopt.Quoted(rapid.Int64()).Draw(t, "score"),
+		}
+	})
+}
+
+func TestVal(t *testing.T) {
+	t.Parallel()
+
+	var empty, present int
+
+	rapid.Check(t, func(t *rapid.T) {
+		val := rapidopt.Val(rapid.IntRange(1, 10)).Draw(t, "val")
+		if !val.HasVal() {
+			empty++
+
+			return
+		}
+
+		present++
+
+		assert.GreaterOrEqual(t, val.Val(), 1)
+		assert.LessOrEqual(t, val.Val(), 10)
+	})
+
+	assert.Positive(t, empty)
+	assert.Positive(t, present)
+}
+
+func TestVal_JSONRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	rapid.Check(t, func(t *rapid.T) {
+		want := userGen().Draw(t, "user")
+
+		buf, err := json.Marshal(want)
+		require.NoError(t, err)
+
+		var got user
+		require.NoError(t, json.Unmarshal(buf, &got))
+		require.Equal(t, want, got)
+	})
+}
@@ -0,0 +1,310 @@
+// Package schema generates JSON Schema (draft 2020-12) for go types with
+// optional.Val fields. Val[T] is described as the schema of T with null
+// allowed, and such properties are not listed in required:
+//
+//	type User struct {
+//		Name      string               `json:"name"`
+//		AvatarURL optional.Val[string] `json:"avatar_url"`
+//	}
+//
+//	s := schema.For[User]()
+//	// {"type":"object","properties":{"avatar_url":{"type":["string","null"]},"name":{"type":"string"}},"required":["name"]}
+//
+// Named struct types are placed into $defs and referenced with $ref.
+package schema
+
+import (
+	"encoding"
+	"encoding/json"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kazhuravlev/optional/internal/valtype"
+)
+
+// Version is the JSON Schema dialect of generated schemas.
+const Version = "https://json-schema.org/draft/2020-12/schema"
+
+// Schema is a subset of JSON Schema which is enough to describe go types.
+type Schema struct {
+	Version              string             `json:"$schema,omitempty"`
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 Types              `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	ContentEncoding      string             `json:"contentEncoding,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	AnyOf                []*Schema          `json:"anyOf,omitempty"`
+	Defs                 map[string]*Schema `json:"$defs,omitempty"`
+}
+
+// Types is a list of JSON types. A single type is encoded as a string.
+type Types []string
+
+// MarshalJSON implements json.Marshaler.
+func (t Types) MarshalJSON() ([]byte, error) {
+	if len(t) == 1 {
+		return json.Marshal(t[0]) //nolint:wrapcheck
+	}
+
+	return json.Marshal([]string(t)) //nolint:wrapcheck
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (t *Types) UnmarshalJSON(buf []byte) error {
+	var single string
+	if err := json.Unmarshal(buf, &single); err == nil {
+		*t = Types{single}
+
+		return nil
+	}
+
+	return json.Unmarshal(buf, (*[]string)(t)) //nolint:wrapcheck
+}
+
+// For returns a root schema of type T.
+func For[T any]() *Schema {
+	return Reflect(reflect.TypeFor[T]())
+}
+
+// Reflect returns a root schema of type t.
+func Reflect(t reflect.Type) *Schema {
+	g := NewGenerator()
+
+	res := g.Schema(t)
+	res.Version = Version
+	if len(g.defs) != 0 {
+		res.Defs = g.defs
+	}
+
+	return res
+}
+
+// Generator generates schemas which share definitions of named structs. Use
+// it to describe several types, for example components of OpenAPI document.
+type Generator struct {
+	defs  map[string]*Schema
+	names map[reflect.Type]string
+}
+
+// NewGenerator returns generator with empty definitions.
+func NewGenerator() *Generator {
+	return &Generator{defs: map[string]*Schema{}, names: map[reflect.Type]string{}}
+}
+
+// Defs returns definitions of named structs, collected by Schema calls.
+func (g *Generator) Defs() map[string]*Schema {
+	return g.defs
+}
+
+// Schema returns schema of type t. Named structs are referenced as #/$defs/Name.
+func (g *Generator) Schema(t reflect.Type) *Schema {
+	return g.schema(t, "#/$defs/")
+}
+
+// SchemaWithRefPrefix is the same as Schema, but uses refPrefix instead of #/$defs/.
+func (g *Generator) SchemaWithRefPrefix(t reflect.Type, refPrefix string) *Schema {
+	return g.schema(t, refPrefix)
+}
+
+var (
+	timeType          = reflect.TypeFor[time.Time]()
+	rawJSONType       = reflect.TypeFor[json.RawMessage]()
+	jsonMarshalerType = reflect.TypeFor[json.Marshaler]()
+	textMarshalerType = reflect.TypeFor[encoding.TextMarshaler]()
+)
+
+func (g *Generator) schema(t reflect.Type, refPrefix string) *Schema {
+	if elem, ok := valtype.Elem(t); ok {
+		return Nullable(g.schema(elem, refPrefix))
+	}
+
+	if elem, ok := valtype.QuotedElem(t); ok {
+		// Quoted is encoded as a string, but unquoted values are accepted too.
+		res := &Schema{Type: Types{"string"}}
+		if typ := g.schema(elem, refPrefix).Type; len(typ) == 1 && typ[0] != "string" {
+			res.Type = append(res.Type, typ[0])
+		}
+
+		res.Type = append(res.Type, "null")
+
+		return res
+	}
+
+	switch t {
+	case timeType:
+		return &Schema{Type: Types{"string"}, Format: "date-time"}
+	case rawJSONType:
+		return &Schema{}
+	}
+
+	if isMarshaler(t) {
+		// Custom marshalers usually encode values like decimals and UUIDs
+		// as strings.
+		return &Schema{Type: Types{"string"}}
+	}
+
+	switch t.Kind() { //nolint:exhaustive
+	case reflect.Bool:
+		return &Schema{Type: Types{"boolean"}}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: Types{"integer"}}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: Types{"number"}}
+	case reflect.String:
+		return &Schema{Type: Types{"string"}}
+	case reflect.Pointer:
+		return g.schema(t.Elem(), refPrefix)
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 && t.Kind() == reflect.Slice {
+			return &Schema{Type: Types{"string"}, ContentEncoding: "base64"}
+		}
+
+		return &Schema{Type: Types{"array"}, Items: g.schema(t.Elem(), refPrefix)}
+	case reflect.Map:
+		return &Schema{Type: Types{"object"}, AdditionalProperties: g.schema(t.Elem(), refPrefix)}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return g.object(t, refPrefix)
+		}
+
+		name, ok := g.names[t]
+		if !ok {
+			name = g.defName(t)
+			g.names[t] = name
+			g.defs[name] = &Schema{} // placeholder for recursive types
+			g.defs[name] = g.object(t, refPrefix)
+		}
+
+		return &Schema{Ref: refPrefix + name}
+	default:
+		// Interfaces and other types may contain any value.
+		return &Schema{}
+	}
+}
+
+// isMarshaler reports whether t or *t implements json.Marshaler or
+// encoding.TextMarshaler.
+func isMarshaler(t reflect.Type) bool {
+	if t.Kind() != reflect.Pointer && t.Kind() != reflect.Interface {
+		t = reflect.PointerTo(t)
+	}
+
+	return t.Implements(jsonMarshalerType) || t.Implements(textMarshalerType)
+}
+
+// Nullable adds null into the list of allowed types of s.
+func Nullable(s *Schema) *Schema {
+	switch {
+	case slices.Contains(s.Type, "null"):
+		return s
+	case len(s.Type) != 0:
+		res := *s
+		res.Type = append(append(Types(nil), s.Type...), "null")
+
+		return &res
+	case s.Ref != "" || len(s.AnyOf) != 0:
+		return &Schema{AnyOf: []*Schema{s, {Type: Types{"null"}}}}
+	default:
+		// Schema without type accepts null already.
+		return s
+	}
+}
+
+func (g *Generator) object(t reflect.Type, refPrefix string) *Schema {
+	res := &Schema{Type: Types{"object"}, Properties: map[string]*Schema{}}
+	g.fields(t, refPrefix, res)
+
+	return res
+}
+
+func (g *Generator) fields(t reflect.Type, refPrefix string, res *Schema) {
+	for i := range t.NumField() {
+		field := t.Field(i)
+
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+
+		name, opts, _ := strings.Cut(tag, ",")
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+
+			if embedded.Kind() == reflect.Struct {
+				g.fields(embedded, refPrefix, res)
+
+				continue
+			}
+		}
+
+		if !field.IsExported() {
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+
+		res.Properties[name] = g.schema(field.Type, refPrefix)
+
+		_, isOptional := valtype.Elem(field.Type)
+		if _, ok := valtype.QuotedElem(field.Type); ok {
+			isOptional = true
+		}
+
+		if !isOptional && !hasOption(opts, "omitempty") && !hasOption(opts, "omitzero") {
+			res.Required = append(res.Required, name)
+		}
+	}
+}
+
+func (g *Generator) defName(t reflect.Type) string {
+	name := sanitize(t.Name())
+	if _, ok := g.defs[name]; !ok {
+		return name
+	}
+
+	// The same name in different packages.
+	pkg := t.PkgPath()
+	name = sanitize(pkg[strings.LastIndex(pkg, "/")+1:]) + "." + name
+	for i, base := 2, name; ; i++ {
+		if _, ok := g.defs[name]; !ok {
+			return name
+		}
+
+		name = base + strconv.Itoa(i)
+	}
+}
+
+func sanitize(name string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '_', r == '.':
+			return r
+		default:
+			return '_'
+		}
+	}, name)
+}
+
+func hasOption(opts, option string) bool {
+	for opts != "" {
+		var opt string
+		opt, opts, _ = strings.Cut(opts, ",")
+		if opt == option {
+			return true
+		}
+	}
+
+	return false
+}
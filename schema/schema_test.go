@@ -0,0 +1,172 @@
+package schema
+
+import (
+	"encoding/json"
+	"net/netip"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kazhuravlev/optional"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type Address struct {
+	City string `json:"city"`
+}
+
+type Node struct {
+	Value    int                 `json:"value"`
+	Children []Node              `json:"children,omitempty"`
+	Parent   optional.Val[*Node] `json:"parent"`
+}
+
+type Base struct {
+	ID int64 `json:"id"`
+}
+
+type User struct {
+	Base
+
+	Name      string                  `json:"name"`
+	Nickname  optional.Val[string]    `json:"nickname"`
+	Age       optional.Val[int]       `json:"age,omitempty"`
+	Score     optional.Val[float64]   `json:"score"`
+	Tags      optional.Val[[]string]  `json:"tags"`
+	Address   optional.Val[Address]   `json:"address"`
+	Home      Address                 `json:"home"`
+	BirthDate optional.Val[time.Time] `json:"birth_date"`
+	ExtID     optional.Quoted[int64]  `json:"ext_id"`
+	Meta      map[string]any          `json:"meta,omitempty"`
+	Avatar    []byte                  `json:"avatar,omitempty"`
+	Internal  string                  `json:"-"`
+	Untagged  bool
+	private   string //nolint:unused
+}
+
+func TestFor(t *testing.T) {
+	t.Parallel()
+
+	res, err := json.Marshal(For[User]())
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"$ref": "#/$defs/User",
+		"$defs": {
+			"Address": {
+				"type": "object",
+				"properties": {"city": {"type": "string"}},
+				"required": ["city"]
+			},
+			"User": {
+				"type": "object",
+				"properties": {
+					"id": {"type": "integer"},
+					"name": {"type": "string"},
+					"nickname": {"type": ["string", "null"]},
+					"age": {"type": ["integer", "null"]},
+					"score": {"type": ["number", "null"]},
+					"tags": {"type": ["array", "null"], "items": {"type": "string"}},
+					"address": {"anyOf": [{"$ref": "#/$defs/Address"}, {"type": "null"}]},
+					"home": {"$ref": "#/$defs/Address"},
+					"birth_date": {"type": ["string", "null"], "format": "date-time"},
+					"ext_id": {"type": ["string", "integer", "null"]},
+					"meta": {"type": "object", "additionalProperties": {}},
+					"avatar": {"type": "string", "contentEncoding": "base64"},
+					"Untagged": {"type": "boolean"}
+				},
+				"required": ["id", "name", "home", "Untagged"]
+			}
+		}
+	}`, string(res))
+}
+
+func TestRecursive(t *testing.T) {
+	t.Parallel()
+
+	res, err := json.Marshal(For[Node]())
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"$ref": "#/$defs/Node",
+		"$defs": {
+			"Node": {
+				"type": "object",
+				"properties": {
+					"value": {"type": "integer"},
+					"children": {"type": "array", "items": {"$ref": "#/$defs/Node"}},
+					"parent": {"anyOf": [{"$ref": "#/$defs/Node"}, {"type": "null"}]}
+				},
+				"required": ["value"]
+			}
+		}
+	}`, string(res))
+}
+
+// level is encoded as a string by its own marshaler.
+type level int
+
+func (l level) MarshalJSON() ([]byte, error) {
+	return json.Marshal("level-" + strconv.Itoa(int(l))) //nolint:wrapcheck
+}
+
+// token is encoded as a string by its text marshaler with pointer receiver.
+type token struct {
+	parts []string
+}
+
+func (t *token) MarshalText() ([]byte, error) {
+	return []byte(strings.Join(t.parts, ".")), nil
+}
+
+func TestScalars(t *testing.T) {
+	t.Parallel()
+
+	table := []struct {
+		name string
+		in   *Schema
+		exp  string
+	}{
+		{name: "val_string", in: For[optional.Val[string]](), exp: `{"type":["string","null"]}`},
+		{name: "val_bool", in: For[optional.Val[bool]](), exp: `{"type":["boolean","null"]}`},
+		{name: "val_any", in: For[optional.Val[any]](), exp: `{}`},
+		{name: "val_val", in: For[optional.Val[optional.Val[int]]](), exp: `{"type":["integer","null"]}`},
+		{name: "quoted", in: For[optional.Quoted[bool]](), exp: `{"type":["string","boolean","null"]}`},
+		{name: "quoted_marshaler", in: For[optional.Quoted[level]](), exp: `{"type":["string","null"]}`},
+		{name: "json_marshaler", in: For[level](), exp: `{"type":"string"}`},
+		{name: "text_marshaler", in: For[netip.Addr](), exp: `{"type":"string"}`},
+		{name: "text_marshaler_pointer", in: For[optional.Val[*token]](), exp: `{"type":["string","null"]}`},
+		{name: "text_marshaler_pointer_receiver", in: For[[]token](), exp: `{"type":"array","items":{"type":"string"}}`},
+		{name: "raw_json", in: For[json.RawMessage](), exp: `{}`},
+		{name: "anonymous_struct", in: For[struct {
+			A optional.Val[int] `json:"a"`
+		}](), exp: `{"type":"object","properties":{"a":{"type":["integer","null"]}}}`},
+	}
+
+	for i := range table {
+		row := table[i]
+		t.Run(row.name, func(t *testing.T) {
+			t.Parallel()
+
+			row.in.Version = ""
+			res, err := json.Marshal(row.in)
+			require.NoError(t, err)
+			assert.JSONEq(t, row.exp, string(res))
+		})
+	}
+}
+
+func TestTypesUnmarshal(t *testing.T) {
+	t.Parallel()
+
+	var res Schema
+	require.NoError(t, json.Unmarshal([]byte(`{"type":"string"}`), &res))
+	assert.Equal(t, Types{"string"}, res.Type)
+
+	require.NoError(t, json.Unmarshal([]byte(`{"type":["string","null"]}`), &res))
+	assert.Equal(t, Types{"string", "null"}, res.Type)
+}
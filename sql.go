@@ -3,8 +3,8 @@ package optional
 import (
 	"database/sql"
 	"database/sql/driver"
-	"errors"
 	"fmt"
+	"reflect"
 )
 
 // Scan implements the Scanner interface.
@@ -13,7 +13,7 @@ func (v *Val[T]) Scan(value any) error {
 		if err := scanner.Scan(value); err != nil {
 			v.value, v.hasVal = *new(T), false
 
-			return fmt.Errorf("scan value: %w", err)
+			return newDecodeError[T](FormatSQL, err)
 		}
 
 		v.hasVal = true
@@ -27,7 +27,11 @@ func (v *Val[T]) Scan(value any) error {
 
 	val, ok := value.(T)
 	if !ok {
-		return errors.New("unexpected value type")
+		if isArray(reflect.TypeFor[T]()) {
+			return v.scanArray(value)
+		}
+
+		return v.scanConvert(value)
 	}
 
 	v.hasVal = true
@@ -42,8 +46,14 @@ func (v Val[T]) Value() (driver.Value, error) {
 		return nil, nil
 	}
 
-	if v, ok := any(v.value).(driver.Valuer); ok {
-		res, err := v.Value()
+	// Address of value is used to call Value of T with pointer receiver.
+	valuer, ok := any(&v.value).(driver.Valuer)
+	if !ok {
+		valuer, ok = any(v.value).(driver.Valuer)
+	}
+
+	if ok {
+		res, err := valuer.Value()
 		if err != nil {
 			return nil, fmt.Errorf("get driver value: %w", err)
 		}
@@ -51,5 +61,62 @@ func (v Val[T]) Value() (driver.Value, error) {
 		return res, nil
 	}
 
+	if isArray(reflect.TypeFor[T]()) {
+		return string(appendArray(nil, reflect.ValueOf(v.value))), nil
+	}
+
+	// Convert values like int32 to driver.Value the same way as sql.Null does.
+	// Other values are passed as is, drivers may support them.
+	if res, err := driver.DefaultParameterConverter.ConvertValue(v.value); err == nil {
+		return res, nil
+	}
+
 	return v.value, nil
 }
+
+// Scan implements the Scanner interface. See Val.Scan.
+func (v *Quoted[T]) Scan(value any) error {
+	return v.val.Scan(value)
+}
+
+// Value implements the driver Valuer interface. See Val.Value.
+func (v Quoted[T]) Value() (driver.Value, error) {
+	return v.val.Value()
+}
+
+// scanArray scans PostgreSQL array in text format into slice value.
+func (v *Val[T]) scanArray(value any) error {
+	var src string
+	switch value := value.(type) {
+	case []byte:
+		src = string(value)
+	case string:
+		src = value
+	default:
+		return newScanTypeError[T](value, nil)
+	}
+
+	if err := scanArray(reflect.ValueOf(&v.value).Elem(), src); err != nil {
+		v.value, v.hasVal = *new(T), false
+
+		return newDecodeError[T](FormatSQL, err)
+	}
+
+	v.hasVal = true
+
+	return nil
+}
+
+// scanConvert converts value into T the same way as sql.Null does.
+func (v *Val[T]) scanConvert(value any) error {
+	var res sql.Null[T]
+	if err := res.Scan(value); err != nil {
+		v.value, v.hasVal = *new(T), false
+
+		return newScanTypeError[T](value, err)
+	}
+
+	v.value, v.hasVal = res.V, res.Valid
+
+	return nil
+}
@@ -2,6 +2,7 @@ package optional
 
 import (
 	"database/sql"
+	"database/sql/driver"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -126,6 +127,26 @@ func TestScan(t *testing.T) {
 		}
 	})
 
+	t.Run("convert", func(t *testing.T) {
+		t.Parallel()
+
+		var intVal Val[int]
+		require.NoError(t, intVal.Scan(int64(42)))
+		assert.Equal(t, New(42), intVal)
+
+		var strVal Val[string]
+		require.NoError(t, strVal.Scan([]byte("hello")))
+		assert.Equal(t, New("hello"), strVal)
+
+		var floatVal Val[float32]
+		require.NoError(t, floatVal.Scan(1.5))
+		assert.Equal(t, New[float32](1.5), floatVal)
+
+		boolVal := New(true)
+		require.Error(t, boolVal.Scan("not-a-bool"))
+		assert.Equal(t, Empty[bool](), boolVal)
+	})
+
 	t.Run("sql_Scanner_bad_scenario", func(t *testing.T) {
 		var val Val[sql.NullBool]
 		err := val.Scan("not-valid-data")
@@ -152,6 +173,18 @@ func TestValue(t *testing.T) {
 		assert.Equal(t, int64(42), val)
 	})
 
+	t.Run("pointer_receiver_valuer", func(t *testing.T) {
+		t.Parallel()
+
+		val, err := New(ptrValuer{Name: "a"}).Value()
+		require.NoError(t, err)
+		assert.Equal(t, "custom:a", val)
+
+		val, err = New(&ptrValuer{Name: "b"}).Value()
+		require.NoError(t, err)
+		assert.Equal(t, "custom:b", val)
+	})
+
 	t.Run("not_empty_valuer", func(t *testing.T) {
 		t.Parallel()
 
@@ -162,4 +195,36 @@ func TestValue(t *testing.T) {
 		require.NoError(t, err)
 		assert.Equal(t, true, val)
 	})
+
+	t.Run("converted", func(t *testing.T) {
+		t.Parallel()
+
+		val, err := New[int32](42).Value()
+		require.NoError(t, err)
+		assert.Equal(t, int64(42), val)
+
+		val, err = New(float32(1.5)).Value()
+		require.NoError(t, err)
+		assert.Equal(t, 1.5, val)
+
+		str := "hi"
+		val, err = New(&str).Value()
+		require.NoError(t, err)
+		assert.Equal(t, "hi", val)
+
+		type custom struct{ A int }
+
+		val, err = New(custom{A: 1}).Value()
+		require.NoError(t, err)
+		assert.Equal(t, custom{A: 1}, val, "unsupported values are passed to driver as is")
+	})
+}
+
+// ptrValuer implements driver.Valuer with pointer receiver.
+type ptrValuer struct {
+	Name string
+}
+
+func (p *ptrValuer) Value() (driver.Value, error) {
+	return "custom:" + p.Name, nil
 }
@@ -0,0 +1,319 @@
+package optional
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// arrayElem is implemented by *Val[T] to be used as an element of PostgreSQL array.
+// NULL elements are mapped into empty values.
+type arrayElem interface {
+	scanArrayElem(src string, null bool) error
+	appendArrayElem(buf []byte) []byte
+}
+
+var (
+	arrayElemType = reflect.TypeOf((*arrayElem)(nil)).Elem()
+
+	errArrayFormat = errors.New("invalid array format")
+	errArrayNull   = errors.New("NULL element can not be stored into non-optional type")
+)
+
+// isArray returns true when t is a slice of scalars or a slice of Val of scalars.
+// []byte is not an array, because it is stored as bytea.
+func isArray(t reflect.Type) bool {
+	if t.Kind() != reflect.Slice {
+		return false
+	}
+
+	elem := t.Elem()
+	if reflect.PointerTo(elem).Implements(arrayElemType) {
+		field, ok := elem.FieldByName("value")
+
+		return ok && isArrayScalar(field.Type)
+	}
+
+	return elem.Kind() != reflect.Uint8 && isArrayScalar(elem)
+}
+
+func isArrayScalar(t reflect.Type) bool {
+	switch t.Kind() { //nolint:exhaustive
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	default:
+		return false
+	}
+}
+
+// scanArray parses PostgreSQL array text format like `{a,"b c",NULL}` into dst slice.
+func scanArray(dst reflect.Value, src string) error {
+	elems, nulls, err := parseArray(src)
+	if err != nil {
+		return err
+	}
+
+	res := reflect.MakeSlice(dst.Type(), len(elems), len(elems))
+	for i := range elems {
+		item := res.Index(i)
+		if elem, ok := item.Addr().Interface().(arrayElem); ok {
+			if err := elem.scanArrayElem(elems[i], nulls[i]); err != nil {
+				return fmt.Errorf("element %d: %w", i, err)
+			}
+
+			continue
+		}
+
+		if nulls[i] {
+			return fmt.Errorf("element %d: %w", i, errArrayNull)
+		}
+
+		if err := scanArrayScalar(item, elems[i]); err != nil {
+			return fmt.Errorf("element %d: %w", i, err)
+		}
+	}
+
+	dst.Set(res)
+
+	return nil
+}
+
+// appendArray encodes slice src into PostgreSQL array text format.
+func appendArray(buf []byte, src reflect.Value) []byte {
+	buf = append(buf, '{')
+	for i := range src.Len() {
+		if i > 0 {
+			buf = append(buf, ',')
+		}
+
+		item := src.Index(i) // elements of slice are always addressable
+		if elem, ok := item.Addr().Interface().(arrayElem); ok {
+			buf = elem.appendArrayElem(buf)
+
+			continue
+		}
+
+		buf = appendArrayScalar(buf, item)
+	}
+
+	return append(buf, '}')
+}
+
+func scanArrayScalar(dst reflect.Value, src string) error {
+	switch dst.Kind() { //nolint:exhaustive
+	case reflect.String:
+		dst.SetString(src)
+	case reflect.Bool:
+		switch src {
+		case "t", "true":
+			dst.SetBool(true)
+		case "f", "false":
+			dst.SetBool(false)
+		default:
+			return fmt.Errorf("parse bool %q: %w", src, errArrayFormat)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		val, err := strconv.ParseInt(src, 10, dst.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("parse int: %w", err)
+		}
+
+		dst.SetInt(val)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		val, err := strconv.ParseUint(src, 10, dst.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("parse uint: %w", err)
+		}
+
+		dst.SetUint(val)
+	case reflect.Float32, reflect.Float64:
+		switch src {
+		case "Infinity":
+			dst.SetFloat(math.Inf(1))
+		case "-Infinity":
+			dst.SetFloat(math.Inf(-1))
+		default:
+			val, err := strconv.ParseFloat(src, dst.Type().Bits())
+			if err != nil {
+				return fmt.Errorf("parse float: %w", err)
+			}
+
+			dst.SetFloat(val)
+		}
+	default:
+		return fmt.Errorf("unsupported array element type %s", dst.Type())
+	}
+
+	return nil
+}
+
+func appendArrayScalar(buf []byte, src reflect.Value) []byte {
+	switch src.Kind() { //nolint:exhaustive
+	case reflect.String:
+		return appendArrayString(buf, src.String())
+	case reflect.Bool:
+		if src.Bool() {
+			return append(buf, 't')
+		}
+
+		return append(buf, 'f')
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.AppendInt(buf, src.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.AppendUint(buf, src.Uint(), 10)
+	case reflect.Float32, reflect.Float64:
+		val := src.Float()
+		switch {
+		case math.IsInf(val, 1):
+			return append(buf, "Infinity"...)
+		case math.IsInf(val, -1):
+			return append(buf, "-Infinity"...)
+		default:
+			return strconv.AppendFloat(buf, val, 'g', -1, src.Type().Bits())
+		}
+	default:
+		panic("unsupported array element type " + src.Type().String())
+	}
+}
+
+// appendArrayString always quotes the element, so empty strings and
+// strings like NULL are not confused with special values.
+func appendArrayString(buf []byte, src string) []byte {
+	buf = append(buf, '"')
+	for i := range len(src) {
+		if src[i] == '"' || src[i] == '\\' {
+			buf = append(buf, '\\')
+		}
+
+		buf = append(buf, src[i])
+	}
+
+	return append(buf, '"')
+}
+
+// parseArray splits one-dimensional PostgreSQL array into elements. Unquoted
+// NULL is reported through nulls.
+func parseArray(src string) ([]string, []bool, error) {
+	if len(src) < 2 || src[0] != '{' || src[len(src)-1] != '}' {
+		return nil, nil, fmt.Errorf("%w: %q", errArrayFormat, src)
+	}
+
+	body := src[1 : len(src)-1]
+	if strings.TrimSpace(body) == "" {
+		return []string{}, []bool{}, nil
+	}
+
+	var (
+		elems []string
+		nulls []bool
+	)
+
+	for pos := 0; ; {
+		for pos < len(body) && body[pos] == ' ' {
+			pos++
+		}
+
+		var (
+			elem   strings.Builder
+			quoted bool
+		)
+
+		switch {
+		case pos < len(body) && body[pos] == '"':
+			quoted = true
+			pos++
+
+			for {
+				if pos >= len(body) {
+					return nil, nil, fmt.Errorf("%w: unterminated quote in %q", errArrayFormat, src)
+				}
+
+				char := body[pos]
+				if char == '"' {
+					pos++
+
+					break
+				}
+
+				if char == '\\' {
+					pos++
+					if pos >= len(body) {
+						return nil, nil, fmt.Errorf("%w: unterminated escape in %q", errArrayFormat, src)
+					}
+
+					char = body[pos]
+				}
+
+				elem.WriteByte(char)
+				pos++
+			}
+		case pos < len(body) && body[pos] == '{':
+			return nil, nil, fmt.Errorf("%w: multidimensional arrays are not supported", errArrayFormat)
+		default:
+			start := pos
+			for pos < len(body) && body[pos] != ',' {
+				if body[pos] == '"' || body[pos] == '{' || body[pos] == '}' {
+					return nil, nil, fmt.Errorf("%w: unexpected %q in %q", errArrayFormat, body[pos], src)
+				}
+
+				pos++
+			}
+
+			elem.WriteString(strings.TrimSpace(body[start:pos]))
+		}
+
+		for pos < len(body) && body[pos] == ' ' {
+			pos++
+		}
+
+		val := elem.String()
+		if !quoted && val == "" {
+			return nil, nil, fmt.Errorf("%w: empty element in %q", errArrayFormat, src)
+		}
+
+		elems = append(elems, val)
+		nulls = append(nulls, !quoted && strings.EqualFold(val, "NULL"))
+
+		if pos >= len(body) {
+			break
+		}
+
+		if body[pos] != ',' {
+			return nil, nil, fmt.Errorf("%w: expected delimiter in %q", errArrayFormat, src)
+		}
+
+		pos++
+	}
+
+	return elems, nulls, nil
+}
+
+func (v *Val[T]) scanArrayElem(src string, null bool) error {
+	if null {
+		v.Reset()
+
+		return nil
+	}
+
+	if err := scanArrayScalar(reflect.ValueOf(&v.value).Elem(), src); err != nil {
+		return err
+	}
+
+	v.hasVal = true
+
+	return nil
+}
+
+func (v Val[T]) appendArrayElem(buf []byte) []byte {
+	if !v.hasVal {
+		return append(buf, "NULL"...)
+	}
+
+	return appendArrayScalar(buf, reflect.ValueOf(v.value))
+}
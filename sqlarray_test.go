@@ -0,0 +1,186 @@
+package optional
+
+import (
+	"database/sql/driver"
+	"math"
+	"testing"
+
+	"github.com/kazhuravlev/optional/internal/fakedb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseArray(t *testing.T) {
+	t.Parallel()
+
+	table := []struct {
+		in     string
+		elems  []string
+		nulls  []bool
+		expErr bool
+	}{
+		{in: `{}`, elems: []string{}, nulls: []bool{}},
+		{in: `{a}`, elems: []string{"a"}, nulls: []bool{false}},
+		{in: `{a,b}`, elems: []string{"a", "b"}, nulls: []bool{false, false}},
+		{in: `{ a , b }`, elems: []string{"a", "b"}, nulls: []bool{false, false}},
+		{in: `{"a b","c,d"}`, elems: []string{"a b", "c,d"}, nulls: []bool{false, false}},
+		{in: `{"a\"b","c\\d"}`, elems: []string{`a"b`, `c\d`}, nulls: []bool{false, false}},
+		{in: `{""}`, elems: []string{""}, nulls: []bool{false}},
+		{in: `{NULL,null,"NULL"}`, elems: []string{"NULL", "null", "NULL"}, nulls: []bool{true, true, false}},
+		{in: ``, expErr: true},
+		{in: `a,b`, expErr: true},
+		{in: `{a,}`, expErr: true},
+		{in: `{"a}`, expErr: true},
+		{in: `{"a"b}`, expErr: true},
+		{in: `{{1,2},{3,4}}`, expErr: true},
+	}
+
+	for i := range table {
+		row := table[i]
+		t.Run(row.in, func(t *testing.T) {
+			t.Parallel()
+
+			elems, nulls, err := parseArray(row.in)
+			if row.expErr {
+				require.Error(t, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, row.elems, elems)
+			assert.Equal(t, row.nulls, nulls)
+		})
+	}
+}
+
+func TestArrayValue(t *testing.T) {
+	t.Parallel()
+
+	table := []struct {
+		name string
+		in   driver.Valuer
+		exp  driver.Value
+	}{
+		{name: "empty", in: Empty[[]string](), exp: nil},
+		{name: "nil_slice", in: New([]string(nil)), exp: `{}`},
+		{name: "strings", in: New([]string{"a", "b c", `"q"`, `\`, "", "NULL"}), exp: `{"a","b c","\"q\"","\\","","NULL"}`},
+		{name: "int64", in: New([]int64{1, -2, 3}), exp: `{1,-2,3}`},
+		{name: "int32", in: New([]int32{math.MaxInt32}), exp: `{2147483647}`},
+		{name: "uint16", in: New([]uint16{7}), exp: `{7}`},
+		{name: "float64", in: New([]float64{1.5, math.Inf(1), math.Inf(-1)}), exp: `{1.5,Infinity,-Infinity}`},
+		{name: "bool", in: New([]bool{true, false}), exp: `{t,f}`},
+		{name: "optional_strings", in: New([]Val[string]{New("a"), Empty[string](), New("")}), exp: `{"a",NULL,""}`},
+		{name: "optional_int64", in: New([]Val[int64]{Empty[int64](), New[int64](0)}), exp: `{NULL,0}`},
+		{name: "bytes_are_not_array", in: New([]byte("hi")), exp: []byte("hi")},
+	}
+
+	for i := range table {
+		row := table[i]
+		t.Run(row.name, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := row.in.Value()
+			require.NoError(t, err)
+			assert.Equal(t, row.exp, res)
+		})
+	}
+}
+
+func TestArrayScan(t *testing.T) {
+	t.Parallel()
+
+	t.Run("strings", func(t *testing.T) {
+		t.Parallel()
+
+		var val Val[[]string]
+		require.NoError(t, val.Scan([]byte(`{a,"b c",""}`)))
+		assert.Equal(t, New([]string{"a", "b c", ""}), val)
+
+		require.NoError(t, val.Scan(`{}`))
+		assert.Equal(t, New([]string{}), val)
+
+		require.NoError(t, val.Scan(nil))
+		assert.Equal(t, Empty[[]string](), val)
+	})
+
+	t.Run("int64", func(t *testing.T) {
+		t.Parallel()
+
+		var val Val[[]int64]
+		require.NoError(t, val.Scan(`{1,-2,3}`))
+		assert.Equal(t, New([]int64{1, -2, 3}), val)
+	})
+
+	t.Run("bool", func(t *testing.T) {
+		t.Parallel()
+
+		var val Val[[]bool]
+		require.NoError(t, val.Scan(`{t,f,true,false}`))
+		assert.Equal(t, New([]bool{true, false, true, false}), val)
+	})
+
+	t.Run("optional_elements", func(t *testing.T) {
+		t.Parallel()
+
+		var val Val[[]Val[string]]
+		require.NoError(t, val.Scan(`{a,NULL,"NULL"}`))
+		assert.Equal(t, New([]Val[string]{New("a"), Empty[string](), New("NULL")}), val)
+	})
+
+	t.Run("null_into_non_optional", func(t *testing.T) {
+		t.Parallel()
+
+		val := New([]string{"stale"})
+		require.Error(t, val.Scan(`{a,NULL}`))
+		assert.Equal(t, Empty[[]string](), val)
+	})
+
+	t.Run("invalid_number", func(t *testing.T) {
+		t.Parallel()
+
+		var val Val[[]int64]
+		require.Error(t, val.Scan(`{1,a}`))
+		require.Error(t, val.Scan(`{1,99999999999999999999}`))
+	})
+
+	t.Run("not_an_array_type", func(t *testing.T) {
+		t.Parallel()
+
+		var val Val[int64]
+		require.Error(t, val.Scan(`{1}`))
+	})
+}
+
+func TestArrayRoundtrip(t *testing.T) {
+	t.Parallel()
+
+	ints := New([]Val[int64]{New[int64](1), Empty[int64](), New[int64](3)})
+	strs := New([]string{"a", `b "c"`, "", "NULL"})
+
+	conn := fakedb.New(nil)
+	db := conn.Open()
+	t.Cleanup(func() { _ = db.Close() })
+
+	_, err := db.Exec("INSERT", ints, strs, Empty[[]string]())
+	require.NoError(t, err)
+
+	args := conn.Args()
+	require.Len(t, args, 1)
+	require.Equal(t, []driver.Value{`{1,NULL,3}`, `{"a","b \"c\"","","NULL"}`, nil}, args[0])
+
+	// Read back the values which were sent to the driver.
+	reader := fakedb.New([]string{"ints", "strs", "null"}, args[0]).Open()
+	t.Cleanup(func() { _ = reader.Close() })
+
+	var (
+		resInts Val[[]Val[int64]]
+		resStrs Val[[]string]
+		resNull Val[[]string]
+	)
+
+	require.NoError(t, reader.QueryRow("SELECT").Scan(&resInts, &resStrs, &resNull))
+	assert.Equal(t, ints, resInts)
+	assert.Equal(t, strs, resStrs)
+	assert.Equal(t, Empty[[]string](), resNull)
+}
@@ -0,0 +1,231 @@
+// Package sqlbuild builds fragments of UPDATE and INSERT statements from
+// structs with optional.Val fields. Only present optional fields are included,
+// which makes partial updates straightforward:
+//
+//	type UserPatch struct {
+//		ID   int64                `db:"id"`
+//		Name optional.Val[string] `db:"name"`
+//		Age  optional.Val[int64]  `db:"age"`
+//	}
+//
+//	fields, err := sqlbuild.Collect(UserPatch{ID: 1, Name: optional.New("Bob")})
+//	set, args := fields.Update(sqlbuild.Dollar, 1) // "id = $1, name = $2", []any{int64(1), "Bob"}
+//
+// Column names are taken from `db` tags as is and are not quoted.
+package sqlbuild
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/kazhuravlev/optional/internal/dbfield"
+)
+
+// Placeholder is a style of bind parameters.
+type Placeholder int
+
+const (
+	// Dollar produces $1, $2, ... placeholders (PostgreSQL).
+	Dollar Placeholder = iota
+	// Question produces ? placeholders (MySQL, SQLite).
+	Question
+	// AtName produces @column placeholders. Arguments are wrapped into sql.NamedArg.
+	AtName
+)
+
+// ErrInvalidSource returned when source is not a struct or a pointer to struct.
+var ErrInvalidSource = errors.New("source must be a struct or a pointer to struct")
+
+type column struct {
+	name    string
+	arg     any
+	present bool
+}
+
+// Fields contains columns and arguments collected from a struct.
+type Fields struct {
+	columns []column
+}
+
+// Collect reads all fields with `db` tag from src. Optional fields are
+// converted into arguments through their Value method.
+func Collect(src any) (Fields, error) {
+	val := reflect.ValueOf(src)
+	for val.Kind() == reflect.Pointer {
+		if val.IsNil() {
+			return Fields{}, ErrInvalidSource
+		}
+
+		val = val.Elem()
+	}
+
+	if val.Kind() != reflect.Struct {
+		return Fields{}, ErrInvalidSource
+	}
+
+	fields, err := dbfield.Fields(val.Type())
+	if err != nil {
+		return Fields{}, fmt.Errorf("collect fields: %w", err)
+	}
+
+	columns := make([]column, 0, len(fields))
+	for _, f := range fields {
+		fieldVal := val.FieldByIndex(f.Index).Interface()
+		if !f.Optional {
+			columns = append(columns, column{name: f.Column, arg: fieldVal, present: true})
+
+			continue
+		}
+
+		present := fieldVal.(interface{ HasVal() bool }).HasVal() //nolint:forcetypeassert // checked by dbfield
+
+		var arg any
+		if valuer, ok := fieldVal.(driver.Valuer); ok {
+			arg, err = valuer.Value()
+			if err != nil {
+				return Fields{}, fmt.Errorf("get value of %s: %w", f.Path, err)
+			}
+		}
+
+		columns = append(columns, column{name: f.Column, arg: arg, present: present})
+	}
+
+	return Fields{columns: columns}, nil
+}
+
+// Empty returns true when there are no present columns.
+func (f Fields) Empty() bool {
+	return len(f.present()) == 0
+}
+
+// Columns returns names of present columns.
+func (f Fields) Columns() []string {
+	present := f.present()
+
+	res := make([]string, len(present))
+	for i := range present {
+		res[i] = present[i].name
+	}
+
+	return res
+}
+
+// Args returns arguments of present columns.
+func (f Fields) Args() []any {
+	present := f.present()
+
+	res := make([]any, len(present))
+	for i := range present {
+		res[i] = present[i].arg
+	}
+
+	return res
+}
+
+// Update returns a SET clause body for present columns like `a = $1, b = $2`.
+// start is a number of the first placeholder and used only by Dollar style.
+func (f Fields) Update(style Placeholder, start int) (string, []any) {
+	present := f.present()
+
+	parts := make([]string, len(present))
+	args := make([]any, len(present))
+	for i, c := range present {
+		parts[i] = c.name + " = " + style.placeholder(c.name, start+i)
+		args[i] = style.arg(c.name, c.arg)
+	}
+
+	return strings.Join(parts, ", "), args
+}
+
+// Insert returns a column list and a values list for present columns like
+// `a, b` and `$1, $2`. start is a number of the first placeholder.
+func (f Fields) Insert(style Placeholder, start int) (string, string, []any) {
+	present := f.present()
+
+	names := make([]string, len(present))
+	values := make([]string, len(present))
+	args := make([]any, len(present))
+	for i, c := range present {
+		names[i] = c.name
+		values[i] = style.placeholder(c.name, start+i)
+		args[i] = style.arg(c.name, c.arg)
+	}
+
+	return strings.Join(names, ", "), strings.Join(values, ", "), args
+}
+
+// Coalesce returns a SET clause body for all columns like
+// `a = COALESCE($1, a), b = COALESCE($2, b)`. Empty optional fields are
+// passed as NULL, so the statement has the same shape for any input and
+// keeps current values of empty fields.
+func (f Fields) Coalesce(style Placeholder, start int) (string, []any) {
+	parts := make([]string, len(f.columns))
+	args := make([]any, len(f.columns))
+	for i, c := range f.columns {
+		parts[i] = c.name + " = COALESCE(" + style.placeholder(c.name, start+i) + ", " + c.name + ")"
+		args[i] = style.arg(c.name, c.presentArg())
+	}
+
+	return strings.Join(parts, ", "), args
+}
+
+// Upsert returns a body of `ON CONFLICT ... DO UPDATE SET` clause for present
+// columns like `a = EXCLUDED.a, b = EXCLUDED.b`. Use it together with Insert.
+func (f Fields) Upsert(exclude ...string) string {
+	present := f.present()
+
+	parts := make([]string, 0, len(present))
+	for _, c := range present {
+		if slices.Contains(exclude, c.name) {
+			continue
+		}
+
+		parts = append(parts, c.name+" = EXCLUDED."+c.name)
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+func (f Fields) present() []column {
+	res := make([]column, 0, len(f.columns))
+	for _, c := range f.columns {
+		if c.present {
+			res = append(res, c)
+		}
+	}
+
+	return res
+}
+
+func (c column) presentArg() any {
+	if !c.present {
+		return nil
+	}
+
+	return c.arg
+}
+
+func (p Placeholder) placeholder(name string, num int) string {
+	switch p {
+	case Question:
+		return "?"
+	case AtName:
+		return "@" + name
+	default:
+		return "$" + strconv.Itoa(num)
+	}
+}
+
+func (p Placeholder) arg(name string, arg any) any {
+	if p == AtName {
+		return sql.Named(name, arg)
+	}
+
+	return arg
+}
@@ -0,0 +1,167 @@
+package sqlbuild
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/kazhuravlev/optional"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type Audit struct {
+	UpdatedAt optional.Val[time.Time] `db:"updated_at"`
+}
+
+type userPatch struct {
+	Audit
+
+	ID       int64                `db:"id"`
+	Name     optional.Val[string] `db:"name"`
+	Age      optional.Val[int64]  `db:"age"`
+	Nickname optional.Val[string] `db:"nickname"`
+	Ignored  optional.Val[string] `db:"-"`
+	Untagged optional.Val[string]
+}
+
+func TestCollect(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	patch := userPatch{
+		Audit:    Audit{UpdatedAt: optional.New(now)},
+		ID:       1,
+		Name:     optional.New("Bob"),
+		Age:      optional.Empty[int64](),
+		Nickname: optional.New(""),
+		Ignored:  optional.New("ignored"),
+		Untagged: optional.New("untagged"),
+	}
+
+	for _, src := range []any{patch, &patch} {
+		fields, err := Collect(src)
+		require.NoError(t, err)
+		assert.False(t, fields.Empty())
+		assert.Equal(t, []string{"updated_at", "id", "name", "nickname"}, fields.Columns())
+		assert.Equal(t, []any{now, int64(1), "Bob", ""}, fields.Args())
+	}
+}
+
+func TestCollectErrors(t *testing.T) {
+	t.Parallel()
+
+	t.Run("not_a_struct", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := Collect(42)
+		require.ErrorIs(t, err, ErrInvalidSource)
+	})
+
+	t.Run("nil_pointer", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := Collect((*userPatch)(nil))
+		require.ErrorIs(t, err, ErrInvalidSource)
+	})
+
+	t.Run("duplicate_column", func(t *testing.T) {
+		t.Parallel()
+
+		type dup struct {
+			A optional.Val[string] `db:"a"`
+			B optional.Val[string] `db:"a"`
+		}
+
+		_, err := Collect(dup{})
+		require.Error(t, err)
+	})
+}
+
+func TestUpdate(t *testing.T) {
+	t.Parallel()
+
+	patch := userPatch{
+		ID:   1,
+		Name: optional.New("Bob"),
+		Age:  optional.New[int64](42),
+	}
+
+	fields, err := Collect(patch)
+	require.NoError(t, err)
+
+	t.Run("dollar", func(t *testing.T) {
+		t.Parallel()
+
+		set, args := fields.Update(Dollar, 2)
+		assert.Equal(t, "id = $2, name = $3, age = $4", set)
+		assert.Equal(t, []any{int64(1), "Bob", int64(42)}, args)
+	})
+
+	t.Run("question", func(t *testing.T) {
+		t.Parallel()
+
+		set, args := fields.Update(Question, 1)
+		assert.Equal(t, "id = ?, name = ?, age = ?", set)
+		assert.Equal(t, []any{int64(1), "Bob", int64(42)}, args)
+	})
+
+	t.Run("at_name", func(t *testing.T) {
+		t.Parallel()
+
+		set, args := fields.Update(AtName, 1)
+		assert.Equal(t, "id = @id, name = @name, age = @age", set)
+		assert.Equal(t, []any{
+			sql.Named("id", int64(1)),
+			sql.Named("name", "Bob"),
+			sql.Named("age", int64(42)),
+		}, args)
+	})
+}
+
+func TestInsert(t *testing.T) {
+	t.Parallel()
+
+	fields, err := Collect(userPatch{ID: 1, Age: optional.New[int64](42)})
+	require.NoError(t, err)
+
+	cols, values, args := fields.Insert(Dollar, 1)
+	assert.Equal(t, "id, age", cols)
+	assert.Equal(t, "$1, $2", values)
+	assert.Equal(t, []any{int64(1), int64(42)}, args)
+
+	assert.Equal(t, "age = EXCLUDED.age", fields.Upsert("id"))
+	assert.Equal(t, "id = EXCLUDED.id, age = EXCLUDED.age", fields.Upsert())
+}
+
+func TestCoalesce(t *testing.T) {
+	t.Parallel()
+
+	type patch struct {
+		Name optional.Val[string] `db:"name"`
+		Age  optional.Val[int64]  `db:"age"`
+	}
+
+	fields, err := Collect(patch{Age: optional.New[int64](42)})
+	require.NoError(t, err)
+
+	set, args := fields.Coalesce(Dollar, 1)
+	assert.Equal(t, "name = COALESCE($1, name), age = COALESCE($2, age)", set)
+	assert.Equal(t, []any{nil, int64(42)}, args)
+}
+
+func TestEmpty(t *testing.T) {
+	t.Parallel()
+
+	type patch struct {
+		Name optional.Val[string] `db:"name"`
+	}
+
+	fields, err := Collect(patch{})
+	require.NoError(t, err)
+	assert.True(t, fields.Empty())
+
+	set, args := fields.Update(Dollar, 1)
+	assert.Equal(t, "", set)
+	assert.Empty(t, args)
+}
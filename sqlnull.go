@@ -0,0 +1,112 @@
+package optional
+
+import (
+	"database/sql"
+	"time"
+)
+
+// FromSQLNull create Val from sql.Null. Invalid sql.Null means that value not provided.
+func FromSQLNull[T any](val sql.Null[T]) Val[T] {
+	return fromNull(val.V, val.Valid)
+}
+
+// SQLNull adapt value to sql.Null. It will return invalid sql.Null when value not provided.
+func (v Val[T]) SQLNull() sql.Null[T] {
+	return sql.Null[T]{
+		V:     v.value,
+		Valid: v.hasVal,
+	}
+}
+
+// SQLNull adapt Quoted to sql.Null. See Val.SQLNull.
+func (v Quoted[T]) SQLNull() sql.Null[T] {
+	return v.val.SQLNull()
+}
+
+// FromNullString create Val from sql.NullString.
+func FromNullString(val sql.NullString) Val[string] {
+	return fromNull(val.String, val.Valid)
+}
+
+// ToNullString adapt value to sql.NullString.
+func ToNullString(val Val[string]) sql.NullString {
+	return sql.NullString{String: val.value, Valid: val.hasVal}
+}
+
+// FromNullInt64 create Val from sql.NullInt64.
+func FromNullInt64(val sql.NullInt64) Val[int64] {
+	return fromNull(val.Int64, val.Valid)
+}
+
+// ToNullInt64 adapt value to sql.NullInt64.
+func ToNullInt64(val Val[int64]) sql.NullInt64 {
+	return sql.NullInt64{Int64: val.value, Valid: val.hasVal}
+}
+
+// FromNullInt32 create Val from sql.NullInt32.
+func FromNullInt32(val sql.NullInt32) Val[int32] {
+	return fromNull(val.Int32, val.Valid)
+}
+
+// ToNullInt32 adapt value to sql.NullInt32.
+func ToNullInt32(val Val[int32]) sql.NullInt32 {
+	return sql.NullInt32{Int32: val.value, Valid: val.hasVal}
+}
+
+// FromNullInt16 create Val from sql.NullInt16.
+func FromNullInt16(val sql.NullInt16) Val[int16] {
+	return fromNull(val.Int16, val.Valid)
+}
+
+// ToNullInt16 adapt value to sql.NullInt16.
+func ToNullInt16(val Val[int16]) sql.NullInt16 {
+	return sql.NullInt16{Int16: val.value, Valid: val.hasVal}
+}
+
+// FromNullByte create Val from sql.NullByte.
+func FromNullByte(val sql.NullByte) Val[byte] {
+	return fromNull(val.Byte, val.Valid)
+}
+
+// ToNullByte adapt value to sql.NullByte.
+func ToNullByte(val Val[byte]) sql.NullByte {
+	return sql.NullByte{Byte: val.value, Valid: val.hasVal}
+}
+
+// FromNullFloat64 create Val from sql.NullFloat64.
+func FromNullFloat64(val sql.NullFloat64) Val[float64] {
+	return fromNull(val.Float64, val.Valid)
+}
+
+// ToNullFloat64 adapt value to sql.NullFloat64.
+func ToNullFloat64(val Val[float64]) sql.NullFloat64 {
+	return sql.NullFloat64{Float64: val.value, Valid: val.hasVal}
+}
+
+// FromNullBool create Val from sql.NullBool.
+func FromNullBool(val sql.NullBool) Val[bool] {
+	return fromNull(val.Bool, val.Valid)
+}
+
+// ToNullBool adapt value to sql.NullBool.
+func ToNullBool(val Val[bool]) sql.NullBool {
+	return sql.NullBool{Bool: val.value, Valid: val.hasVal}
+}
+
+// FromNullTime create Val from sql.NullTime.
+func FromNullTime(val sql.NullTime) Val[time.Time] {
+	return fromNull(val.Time, val.Valid)
+}
+
+// ToNullTime adapt value to sql.NullTime.
+func ToNullTime(val Val[time.Time]) sql.NullTime {
+	return sql.NullTime{Time: val.value, Valid: val.hasVal}
+}
+
+func fromNull[T any](val T, valid bool) Val[T] {
+	if !valid {
+		return Empty[T]()
+	}
+
+	return New(val)
+}
@@ -0,0 +1,215 @@
+package optional
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSQLNull(t *testing.T) {
+	t.Parallel()
+
+	t.Run("from", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(t, New(42), FromSQLNull(sql.Null[int]{V: 42, Valid: true}))
+		assert.Equal(t, New(0), FromSQLNull(sql.Null[int]{V: 0, Valid: true}))
+		assert.Equal(t, Empty[int](), FromSQLNull(sql.Null[int]{V: 42, Valid: false}))
+	})
+
+	t.Run("to", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(t, sql.Null[string]{V: "hi", Valid: true}, New("hi").SQLNull())
+		assert.Equal(t, sql.Null[string]{V: "", Valid: true}, New("").SQLNull())
+		assert.Equal(t, sql.Null[string]{V: "", Valid: false}, Empty[string]().SQLNull())
+	})
+}
+
+func TestNullTypes(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	assert.Equal(t, New("hi"), FromNullString(sql.NullString{String: "hi", Valid: true}))
+	assert.Equal(t, Empty[string](), FromNullString(sql.NullString{String: "hi", Valid: false}))
+	assert.Equal(t, sql.NullString{String: "hi", Valid: true}, ToNullString(New("hi")))
+	assert.Equal(t, sql.NullString{}, ToNullString(Empty[string]()))
+
+	assert.Equal(t, New[int64](42), FromNullInt64(sql.NullInt64{Int64: 42, Valid: true}))
+	assert.Equal(t, Empty[int64](), FromNullInt64(sql.NullInt64{}))
+	assert.Equal(t, sql.NullInt64{Int64: 42, Valid: true}, ToNullInt64(New[int64](42)))
+	assert.Equal(t, sql.NullInt64{}, ToNullInt64(Empty[int64]()))
+
+	assert.Equal(t, New[int32](42), FromNullInt32(sql.NullInt32{Int32: 42, Valid: true}))
+	assert.Equal(t, Empty[int32](), FromNullInt32(sql.NullInt32{}))
+	assert.Equal(t, sql.NullInt32{Int32: 42, Valid: true}, ToNullInt32(New[int32](42)))
+	assert.Equal(t, sql.NullInt32{}, ToNullInt32(Empty[int32]()))
+
+	assert.Equal(t, New[int16](42), FromNullInt16(sql.NullInt16{Int16: 42, Valid: true}))
+	assert.Equal(t, Empty[int16](), FromNullInt16(sql.NullInt16{}))
+	assert.Equal(t, sql.NullInt16{Int16: 42, Valid: true}, ToNullInt16(New[int16](42)))
+	assert.Equal(t, sql.NullInt16{}, ToNullInt16(Empty[int16]()))
+
+	assert.Equal(t, New[byte](42), FromNullByte(sql.NullByte{Byte: 42, Valid: true}))
+	assert.Equal(t, Empty[byte](), FromNullByte(sql.NullByte{}))
+	assert.Equal(t, sql.NullByte{Byte: 42, Valid: true}, ToNullByte(New[byte](42)))
+	assert.Equal(t, sql.NullByte{}, ToNullByte(Empty[byte]()))
+
+	assert.Equal(t, New(4.2), FromNullFloat64(sql.NullFloat64{Float64: 4.2, Valid: true}))
+	assert.Equal(t, Empty[float64](), FromNullFloat64(sql.NullFloat64{}))
+	assert.Equal(t, sql.NullFloat64{Float64: 4.2, Valid: true}, ToNullFloat64(New(4.2)))
+	assert.Equal(t, sql.NullFloat64{}, ToNullFloat64(Empty[float64]()))
+
+	assert.Equal(t, New(false), FromNullBool(sql.NullBool{Bool: false, Valid: true}))
+	assert.Equal(t, Empty[bool](), FromNullBool(sql.NullBool{}))
+	assert.Equal(t, sql.NullBool{Bool: false, Valid: true}, ToNullBool(New(false)))
+	assert.Equal(t, sql.NullBool{}, ToNullBool(Empty[bool]()))
+
+	assert.Equal(t, New(now), FromNullTime(sql.NullTime{Time: now, Valid: true}))
+	assert.Equal(t, Empty[time.Time](), FromNullTime(sql.NullTime{}))
+	assert.Equal(t, sql.NullTime{Time: now, Valid: true}, ToNullTime(New(now)))
+	assert.Equal(t, sql.NullTime{}, ToNullTime(Empty[time.Time]()))
+}
+
+// checkLikeSQLNull scans the same driver value into Val and sql.Null and
+// ensures that both containers behave identically.
+func checkLikeSQLNull[T any](t *testing.T, in driver.Value) {
+	t.Helper()
+
+	var (
+		opt  Val[T]
+		null sql.Null[T]
+	)
+
+	if err := null.Scan(in); err != nil {
+		require.Error(t, opt.Scan(in))
+		assert.Equal(t, Empty[T](), opt)
+
+		return
+	}
+
+	require.NoError(t, opt.Scan(in))
+	assert.Equal(t, FromSQLNull(null), opt)
+
+	optValue, err := opt.Value()
+	require.NoError(t, err)
+	assert.True(t, optValue == nil || driver.IsValue(optValue), "%T is not a driver value", optValue)
+
+	nullValue, err := null.Value()
+	require.NoError(t, err)
+
+	// sql.Null converts values like int32 into driver values since Go 1.24.
+	nullValue, err = driver.DefaultParameterConverter.ConvertValue(nullValue)
+	require.NoError(t, err)
+
+	assert.Equal(t, nullValue, optValue)
+}
+
+func TestScanValueLikeSQLNull(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	t.Run("int64", func(t *testing.T) {
+		t.Parallel()
+
+		checkLikeSQLNull[int64](t, int64(42))
+		checkLikeSQLNull[int64](t, int64(0))
+		checkLikeSQLNull[int64](t, nil)
+	})
+
+	t.Run("float64", func(t *testing.T) {
+		t.Parallel()
+
+		checkLikeSQLNull[float64](t, 4.2)
+		checkLikeSQLNull[float64](t, float64(0))
+		checkLikeSQLNull[float64](t, nil)
+	})
+
+	t.Run("bool", func(t *testing.T) {
+		t.Parallel()
+
+		checkLikeSQLNull[bool](t, true)
+		checkLikeSQLNull[bool](t, false)
+		checkLikeSQLNull[bool](t, nil)
+	})
+
+	t.Run("bytes", func(t *testing.T) {
+		t.Parallel()
+
+		checkLikeSQLNull[[]byte](t, []byte("hi"))
+		checkLikeSQLNull[[]byte](t, []byte{})
+		checkLikeSQLNull[[]byte](t, nil)
+	})
+
+	t.Run("string", func(t *testing.T) {
+		t.Parallel()
+
+		checkLikeSQLNull[string](t, "hi")
+		checkLikeSQLNull[string](t, "")
+		checkLikeSQLNull[string](t, nil)
+	})
+
+	t.Run("time", func(t *testing.T) {
+		t.Parallel()
+
+		checkLikeSQLNull[time.Time](t, now)
+		checkLikeSQLNull[time.Time](t, time.Time{})
+		checkLikeSQLNull[time.Time](t, nil)
+	})
+
+	t.Run("bytes_to_string", func(t *testing.T) {
+		t.Parallel()
+
+		checkLikeSQLNull[string](t, []byte("hi"))
+		checkLikeSQLNull[string](t, []byte{})
+	})
+
+	t.Run("string_to_bytes", func(t *testing.T) {
+		t.Parallel()
+
+		checkLikeSQLNull[[]byte](t, "hi")
+	})
+
+	t.Run("int64_to_narrow_int", func(t *testing.T) {
+		t.Parallel()
+
+		checkLikeSQLNull[int32](t, int64(42))
+		checkLikeSQLNull[int32](t, int64(math.MaxInt64))
+		checkLikeSQLNull[int16](t, int64(-42))
+		checkLikeSQLNull[int](t, int64(42))
+		checkLikeSQLNull[uint8](t, int64(-1))
+	})
+
+	t.Run("float64_to_float32", func(t *testing.T) {
+		t.Parallel()
+
+		checkLikeSQLNull[float32](t, 1.5)
+		checkLikeSQLNull[float32](t, float64(0))
+	})
+
+	t.Run("text_to_number", func(t *testing.T) {
+		t.Parallel()
+
+		checkLikeSQLNull[int64](t, "42")
+		checkLikeSQLNull[int32](t, []byte("42"))
+		checkLikeSQLNull[float64](t, []byte("4.2"))
+		checkLikeSQLNull[bool](t, "true")
+		checkLikeSQLNull[int64](t, "not a number")
+	})
+
+	t.Run("number_to_string", func(t *testing.T) {
+		t.Parallel()
+
+		checkLikeSQLNull[string](t, int64(42))
+		checkLikeSQLNull[string](t, 4.2)
+		checkLikeSQLNull[string](t, true)
+		checkLikeSQLNull[string](t, now)
+	})
+}
@@ -0,0 +1,24 @@
+package sqlscan
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"testing"
+
+	"github.com/kazhuravlev/optional/internal/fakedb"
+	"github.com/stretchr/testify/require"
+)
+
+// query returns rows with given columns and values from the fake driver.
+func query(t *testing.T, columns []string, rows ...[]driver.Value) *sql.Rows {
+	t.Helper()
+
+	db := fakedb.New(columns, rows...).Open()
+	t.Cleanup(func() { _ = db.Close() })
+
+	res, err := db.Query("SELECT")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = res.Close() })
+
+	return res
+}
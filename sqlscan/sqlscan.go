@@ -0,0 +1,174 @@
+// Package sqlscan scans rows of database/sql into structs with `db` tags.
+// Optional fields (like optional.Val) are scanned with their Scan method, so
+// NULL becomes an empty value:
+//
+//	type User struct {
+//		ID       int64                `db:"id"`
+//		Nickname optional.Val[string] `db:"nickname"`
+//	}
+//
+//	rows, err := db.QueryContext(ctx, "SELECT id, nickname FROM users")
+//	...
+//	users, err := sqlscan.ScanAll[User](rows)
+//
+// Optional fields which are missing from the result stay empty. Missing
+// non-optional fields are reported as an error.
+package sqlscan
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/kazhuravlev/optional"
+	"github.com/kazhuravlev/optional/internal/dbfield"
+)
+
+var (
+	// ErrInvalidDestination returned when destination is not a non-nil pointer to struct.
+	ErrInvalidDestination = errors.New("destination must be a non-nil pointer to struct")
+	// ErrUnknownColumn returned when result contains a column without destination field.
+	ErrUnknownColumn = errors.New("unknown column")
+	// ErrMissingColumn returned when a non-optional field has no column in result.
+	ErrMissingColumn = errors.New("missing column")
+)
+
+// ScanStruct scans the current row into dst. dst must be a pointer to struct.
+// Call it after rows.Next, like rows.Scan.
+func ScanStruct(rows *sql.Rows, dst any) error {
+	val := reflect.ValueOf(dst)
+	if val.Kind() != reflect.Pointer || val.IsNil() || val.Elem().Kind() != reflect.Struct {
+		return ErrInvalidDestination
+	}
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return fmt.Errorf("get columns: %w", err)
+	}
+
+	plan, err := newPlan(val.Elem().Type(), columns)
+	if err != nil {
+		return err
+	}
+
+	return plan.scan(rows, val.Elem())
+}
+
+// ScanAll scans all rows into a slice of T and closes rows. T must be a struct.
+func ScanAll[T any](rows *sql.Rows) ([]T, error) {
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, fmt.Errorf("get columns: %w", err)
+	}
+
+	typ := reflect.TypeOf((*T)(nil)).Elem()
+	if typ.Kind() != reflect.Struct {
+		return nil, ErrInvalidDestination
+	}
+
+	plan, err := newPlan(typ, columns)
+	if err != nil {
+		return nil, err
+	}
+
+	var res []T
+	for rows.Next() {
+		var item T
+		if err := plan.scan(rows, reflect.ValueOf(&item).Elem()); err != nil {
+			return nil, err
+		}
+
+		res = append(res, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate rows: %w", err)
+	}
+
+	return res, nil
+}
+
+// plan contains destination fields for each column of result and optional
+// fields which are missing from the result.
+type plan struct {
+	targets []dbfield.Field
+	missing []dbfield.Field
+}
+
+func newPlan(typ reflect.Type, columns []string) (*plan, error) {
+	fields, err := dbfield.Fields(typ)
+	if err != nil {
+		return nil, fmt.Errorf("get fields: %w", err)
+	}
+
+	byColumn := make(map[string]dbfield.Field, len(fields))
+	for _, f := range fields {
+		byColumn[f.Column] = f
+	}
+
+	targets := make([]dbfield.Field, len(columns))
+	for i, col := range columns {
+		f, ok := byColumn[col]
+		if !ok {
+			return nil, fmt.Errorf("%w %q in %s", ErrUnknownColumn, col, typ)
+		}
+
+		targets[i] = f
+		delete(byColumn, col)
+	}
+
+	var missing []dbfield.Field
+	for _, f := range fields {
+		if _, ok := byColumn[f.Column]; !ok {
+			continue
+		}
+
+		if !f.Optional {
+			return nil, fmt.Errorf("%w %q for field %s.%s", ErrMissingColumn, f.Column, typ, f.Path)
+		}
+
+		missing = append(missing, f)
+	}
+
+	return &plan{targets: targets, missing: missing}, nil
+}
+
+func (p *plan) scan(rows *sql.Rows, dst reflect.Value) error {
+	ptrs := make([]any, len(p.targets))
+	for i, f := range p.targets {
+		ptr := dst.FieldByIndex(f.Index).Addr().Interface()
+		if scanner, ok := ptr.(sql.Scanner); ok && f.Optional {
+			ptr = fieldScanner{dst: scanner, path: f.Path}
+		}
+
+		ptrs[i] = ptr
+	}
+
+	if err := rows.Scan(ptrs...); err != nil {
+		return fmt.Errorf("scan row: %w", err)
+	}
+
+	for _, f := range p.missing {
+		field := dst.FieldByIndex(f.Index)
+		field.Set(reflect.Zero(field.Type()))
+	}
+
+	return nil
+}
+
+// fieldScanner adds path of the field into errors of optional values.
+type fieldScanner struct {
+	dst  sql.Scanner
+	path string
+}
+
+func (s fieldScanner) Scan(value any) error {
+	if err := s.dst.Scan(value); err != nil {
+		return optional.WithPath(err, s.path)
+	}
+
+	return nil
+}
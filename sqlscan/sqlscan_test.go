@@ -0,0 +1,132 @@
+package sqlscan
+
+import (
+	"database/sql/driver"
+	"reflect"
+	"testing"
+
+	"github.com/kazhuravlev/optional"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type Base struct {
+	ID int64 `db:"id"`
+}
+
+type user struct {
+	Base
+
+	Name     string               `db:"name"`
+	Nickname optional.Val[string] `db:"nickname"`
+	Age      optional.Val[int64]  `db:"age"`
+	Skipped  string               `db:"-"`
+}
+
+func TestScanAll(t *testing.T) {
+	t.Parallel()
+
+	rows := query(t, []string{"id", "name", "nickname", "age"},
+		[]driver.Value{int64(1), "Alice", "al", int64(30)},
+		[]driver.Value{int64(2), "Bob", nil, int64(0)},
+		[]driver.Value{int64(3), []byte("Carol"), nil, nil},
+	)
+
+	res, err := ScanAll[user](rows)
+	require.NoError(t, err)
+	assert.Equal(t, []user{
+		{Base: Base{ID: 1}, Name: "Alice", Nickname: optional.New("al"), Age: optional.New[int64](30)},
+		{Base: Base{ID: 2}, Name: "Bob", Nickname: optional.Empty[string](), Age: optional.New[int64](0)},
+		{Base: Base{ID: 3}, Name: "Carol", Nickname: optional.Empty[string](), Age: optional.Empty[int64]()},
+	}, res)
+}
+
+func TestScanAllEmpty(t *testing.T) {
+	t.Parallel()
+
+	res, err := ScanAll[user](query(t, []string{"id", "name"}))
+	require.NoError(t, err)
+	assert.Empty(t, res)
+}
+
+func TestScanStruct(t *testing.T) {
+	t.Parallel()
+
+	rows := query(t, []string{"name", "id"},
+		[]driver.Value{"Alice", int64(1)},
+	)
+	require.True(t, rows.Next())
+
+	// Optional fields which are missing from result must be reset.
+	res := user{Nickname: optional.New("stale"), Skipped: "keep"}
+	require.NoError(t, ScanStruct(rows, &res))
+	assert.Equal(t, user{Base: Base{ID: 1}, Name: "Alice", Skipped: "keep"}, res)
+}
+
+func TestScanErrors(t *testing.T) {
+	t.Parallel()
+
+	t.Run("missing_required_column", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := ScanAll[user](query(t, []string{"id", "nickname"}))
+		require.ErrorIs(t, err, ErrMissingColumn)
+		assert.Contains(t, err.Error(), "name")
+	})
+
+	t.Run("unknown_column", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := ScanAll[user](query(t, []string{"id", "name", "email"}))
+		require.ErrorIs(t, err, ErrUnknownColumn)
+	})
+
+	t.Run("invalid_destination", func(t *testing.T) {
+		t.Parallel()
+
+		rows := query(t, []string{"id", "name"}, []driver.Value{int64(1), "Alice"})
+		require.True(t, rows.Next())
+
+		require.ErrorIs(t, ScanStruct(rows, user{}), ErrInvalidDestination)
+		require.ErrorIs(t, ScanStruct(rows, (*user)(nil)), ErrInvalidDestination)
+
+		_, err := ScanAll[int](query(t, []string{"id"}))
+		require.ErrorIs(t, err, ErrInvalidDestination)
+	})
+
+	t.Run("type_mismatch", func(t *testing.T) {
+		t.Parallel()
+
+		rows := query(t, []string{"id", "name", "age"},
+			[]driver.Value{int64(1), "Alice", "not-a-number"},
+		)
+
+		_, err := ScanAll[user](rows)
+		require.ErrorIs(t, err, optional.ErrTypeMismatch)
+
+		var scanErr *optional.ScanTypeError
+		require.ErrorAs(t, err, &scanErr)
+		assert.Equal(t, "Age", scanErr.Path)
+		assert.Equal(t, reflect.TypeFor[int64](), scanErr.Want)
+		assert.Equal(t, reflect.TypeFor[string](), scanErr.Got)
+	})
+
+	t.Run("embedded_path", func(t *testing.T) {
+		t.Parallel()
+
+		type Audit struct {
+			Version optional.Val[int64] `db:"version"`
+		}
+
+		type row struct {
+			Audit
+		}
+
+		_, err := ScanAll[row](query(t, []string{"version"}, []driver.Value{"x"}))
+
+		var scanErr *optional.ScanTypeError
+		require.ErrorAs(t, err, &scanErr)
+		assert.Equal(t, "Audit.Version", scanErr.Path)
+		assert.Contains(t, err.Error(), "field Audit.Version: unexpected value type")
+	})
+}
@@ -0,0 +1,60 @@
+package optional
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+)
+
+// MarshalText implements the encoding.TextMarshaler interface. Present
+// values are encoded by T, which must implement encoding.TextMarshaler.
+// Empty values are encoded as empty text.
+func (v Val[T]) MarshalText() ([]byte, error) {
+	if !v.hasVal {
+		return []byte{}, nil
+	}
+
+	marshaler, ok := any(v.value).(encoding.TextMarshaler)
+	if !ok {
+		// Address of value is used to call marshalers of T with pointer receiver.
+		marshaler, ok = any(&v.value).(encoding.TextMarshaler)
+	}
+
+	if !ok {
+		return nil, fmt.Errorf("marshal optional value of type %s to text: %w", reflect.TypeFor[T](), ErrTextUnsupported)
+	}
+
+	return marshaler.MarshalText() //nolint:wrapcheck
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface. Empty
+// text means that value not provided, other text is decoded by T, which must
+// implement encoding.TextUnmarshaler. Note that present values encoded as
+// empty text are decoded as empty.
+func (v *Val[T]) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		v.Reset()
+		return nil
+	}
+
+	var val T
+	if typ := reflect.TypeFor[T](); typ.Kind() == reflect.Pointer {
+		val = reflect.New(typ.Elem()).Interface().(T) //nolint:forcetypeassert
+	}
+
+	unmarshaler, ok := any(val).(encoding.TextUnmarshaler)
+	if !ok {
+		unmarshaler, ok = any(&val).(encoding.TextUnmarshaler)
+	}
+
+	if !ok {
+		return newDecodeError[T](FormatText, ErrTextUnsupported)
+	}
+
+	if err := unmarshaler.UnmarshalText(text); err != nil {
+		return newDecodeError[T](FormatText, err)
+	}
+
+	v.Set(val)
+	return nil
+}
@@ -0,0 +1,130 @@
+package optional
+
+import (
+	"encoding/json"
+	"math/big"
+	"net/netip"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// upper implements text codecs with pointer receivers.
+type upper string
+
+func (u *upper) MarshalText() ([]byte, error) {
+	return []byte(strings.ToUpper(string(*u))), nil
+}
+
+func (u *upper) UnmarshalText(text []byte) error {
+	*u = upper(strings.ToLower(string(text)))
+
+	return nil
+}
+
+func TestVal_MarshalText(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	table := []struct {
+		name string
+		in   interface{ MarshalText() ([]byte, error) }
+		exp  string
+	}{
+		{name: "time", in: New(now), exp: "2024-01-02T03:04:05Z"},
+		{name: "addr", in: New(netip.MustParseAddr("10.0.0.1")), exp: "10.0.0.1"},
+		{name: "pointer_receiver", in: New(upper("hi")), exp: "HI"},
+		{name: "pointer", in: New(big.NewInt(42)), exp: "42"},
+		{name: "empty", in: Empty[time.Time](), exp: ""},
+		{name: "empty_unsupported", in: Empty[int](), exp: ""},
+	}
+
+	for _, row := range table {
+		t.Run(row.name, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := row.in.MarshalText()
+			require.NoError(t, err)
+			assert.Equal(t, row.exp, string(res))
+		})
+	}
+
+	t.Run("unsupported", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := New(42).MarshalText()
+		require.ErrorIs(t, err, ErrTextUnsupported)
+	})
+}
+
+func TestVal_UnmarshalText(t *testing.T) {
+	t.Parallel()
+
+	t.Run("present", func(t *testing.T) {
+		t.Parallel()
+
+		var addr Val[netip.Addr]
+		require.NoError(t, addr.UnmarshalText([]byte("10.0.0.1")))
+		assert.Equal(t, New(netip.MustParseAddr("10.0.0.1")), addr)
+
+		var up Val[upper]
+		require.NoError(t, up.UnmarshalText([]byte("HI")))
+		assert.Equal(t, New(upper("hi")), up)
+
+		var num Val[*big.Int]
+		require.NoError(t, num.UnmarshalText([]byte("42")))
+		assert.Equal(t, New(big.NewInt(42)), num)
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		t.Parallel()
+
+		addr := New(netip.MustParseAddr("10.0.0.1"))
+		require.NoError(t, addr.UnmarshalText(nil))
+		assert.Equal(t, Empty[netip.Addr](), addr)
+
+		num := New(42)
+		require.NoError(t, num.UnmarshalText([]byte{}))
+		assert.Equal(t, Empty[int](), num)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		t.Parallel()
+
+		var addr Val[netip.Addr]
+		err := addr.UnmarshalText([]byte("nope"))
+		require.ErrorIs(t, err, ErrDecode)
+
+		var decodeErr *DecodeError
+		require.ErrorAs(t, err, &decodeErr)
+		assert.Equal(t, FormatText, decodeErr.Format)
+		assert.Equal(t, Empty[netip.Addr](), addr)
+	})
+
+	t.Run("unsupported", func(t *testing.T) {
+		t.Parallel()
+
+		var num Val[int]
+		err := num.UnmarshalText([]byte("42"))
+		require.ErrorIs(t, err, ErrDecode)
+		require.ErrorIs(t, err, ErrTextUnsupported)
+	})
+
+	t.Run("map_key", func(t *testing.T) {
+		t.Parallel()
+
+		addr := netip.MustParseAddr("10.0.0.1")
+
+		buf, err := json.Marshal(map[Val[netip.Addr]]int{New(addr): 1})
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"10.0.0.1":1}`, string(buf))
+
+		var res map[Val[netip.Addr]]int
+		require.NoError(t, json.Unmarshal(buf, &res))
+		assert.Equal(t, map[Val[netip.Addr]]int{New(addr): 1}, res)
+	})
+}
@@ -1,21 +1,58 @@
 package optional
 
 import (
+	"errors"
 	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+	"sync/atomic"
 
 	"gopkg.in/yaml.v3"
 )
 
-// UnmarshalYAML implements the yaml.Unmarshaler interface.
+// YAMLDecodeOptions controls how UnmarshalYAML decodes the inner value.
+type YAMLDecodeOptions struct {
+	// KnownFields is the same as yaml.Decoder.KnownFields.
+	KnownFields bool
+}
+
+var yamlDecodeOptions atomic.Pointer[YAMLDecodeOptions]
+
+// SetYAMLDecodeOptions sets options which are used by UnmarshalYAML of all
+// Val values. yaml.Node.Decode does not inherit settings of yaml.Decoder, so
+// set here the same options as for the decoder.
+func SetYAMLDecodeOptions(opts YAMLDecodeOptions) {
+	yamlDecodeOptions.Store(&opts)
+}
+
+// GetYAMLDecodeOptions returns options which are used by UnmarshalYAML.
+func GetYAMLDecodeOptions() YAMLDecodeOptions {
+	if opts := yamlDecodeOptions.Load(); opts != nil {
+		return *opts
+	}
+
+	return YAMLDecodeOptions{
+		KnownFields: false,
+	}
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface. Any node resolved
+// to !!null tag (null, Null, NULL, ~, empty value or explicit !!null) means
+// that value not provided. Quoted "null" is a regular string.
+//
+// Note that yaml.Decoder does not call UnmarshalYAML for null nodes of struct
+// fields and keeps the previous value of the field, so decode into empty values.
 func (v *Val[T]) UnmarshalYAML(node *yaml.Node) error {
-	if node.Kind == yaml.ScalarNode && node.Value == "null" {
+	if isYAMLNull(node) {
 		v.hasVal = false
 		v.value = *new(T)
 		return nil
 	}
 
-	if err := node.Decode(&v.value); err != nil {
-		return fmt.Errorf("unmarshal yaml value: %w", err)
+	if err := decodeYAML(node, &v.value); err != nil {
+		return newDecodeError[T](FormatYAML, err)
 	}
 
 	v.hasVal = true
@@ -28,5 +65,188 @@ func (v Val[T]) MarshalYAML() (interface{}, error) {
 		return nil, nil
 	}
 
-	return v.value, nil
+	// Address of value is used to call marshalers of T with pointer receiver.
+	return &v.value, nil
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface. YAML has own
+// types for scalars, so Quoted is decoded the same way as Val.
+func (v *Quoted[T]) UnmarshalYAML(node *yaml.Node) error {
+	return v.val.UnmarshalYAML(node)
+}
+
+// MarshalYAML implements the yaml.Marshaler interface. See UnmarshalYAML.
+func (v Quoted[T]) MarshalYAML() (interface{}, error) {
+	return v.val.MarshalYAML()
+}
+
+func isYAMLNull(node *yaml.Node) bool {
+	for node.Kind == yaml.AliasNode && node.Alias != nil {
+		node = node.Alias
+	}
+
+	return node.IsZero() || (node.Kind == yaml.ScalarNode && node.ShortTag() == "!!null")
+}
+
+func decodeYAML(node *yaml.Node, dst any) error {
+	err := node.Decode(dst)
+	if !GetYAMLDecodeOptions().KnownFields {
+		return err //nolint:wrapcheck
+	}
+
+	// Node.Decode has no options, so unknown fields are found by walking the
+	// node against the type the same way as yaml.Decoder.KnownFields does.
+	// Aliases are resolved in place and errors refer to lines of the document.
+	var unknown []string
+	checkYAMLFields(node, reflect.TypeOf(dst).Elem(), &unknown)
+
+	if len(unknown) == 0 {
+		return err //nolint:wrapcheck
+	}
+
+	var typeErr *yaml.TypeError
+	switch {
+	case err == nil:
+		return &yaml.TypeError{Errors: unknown}
+	case errors.As(err, &typeErr):
+		typeErr.Errors = append(typeErr.Errors, unknown...)
+		return typeErr
+	default:
+		return err //nolint:wrapcheck
+	}
+}
+
+// yamlObsoleteUnmarshaler is the same as obsoleteUnmarshaler from yaml.v3.
+type yamlObsoleteUnmarshaler interface {
+	UnmarshalYAML(unmarshal func(interface{}) error) error
+}
+
+// checkYAMLFields appends errors about mapping keys of node which are not
+// fields of typ. Types with own UnmarshalYAML check their nodes by themselves.
+func checkYAMLFields(node *yaml.Node, typ reflect.Type, errs *[]string) {
+	for node.Kind == yaml.AliasNode && node.Alias != nil {
+		node = node.Alias
+	}
+
+	ptr := reflect.PointerTo(typ)
+	if ptr.Implements(reflect.TypeFor[yaml.Unmarshaler]()) ||
+		ptr.Implements(reflect.TypeFor[yamlObsoleteUnmarshaler]()) {
+		return
+	}
+
+	switch typ.Kind() { //nolint:exhaustive
+	case reflect.Pointer:
+		checkYAMLFields(node, typ.Elem(), errs)
+	case reflect.Slice, reflect.Array:
+		if node.Kind == yaml.SequenceNode {
+			for _, item := range node.Content {
+				checkYAMLFields(item, typ.Elem(), errs)
+			}
+		}
+	case reflect.Map:
+		if node.Kind == yaml.MappingNode {
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				checkYAMLFields(node.Content[i+1], typ.Elem(), errs)
+			}
+		}
+	case reflect.Struct:
+		if node.Kind == yaml.MappingNode {
+			checkYAMLStruct(node, typ, errs)
+		}
+	}
+}
+
+func checkYAMLStruct(node *yaml.Node, typ reflect.Type, errs *[]string) {
+	fields, inlineMap := yamlFields(typ)
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		for key.Kind == yaml.AliasNode && key.Alias != nil {
+			key = key.Alias
+		}
+
+		if key.Kind == yaml.ScalarNode && key.ShortTag() == "!!merge" {
+			checkYAMLMerge(value, typ, errs)
+			continue
+		}
+
+		switch field, ok := fields[key.Value]; {
+		case ok:
+			checkYAMLFields(value, field, errs)
+		case inlineMap != nil:
+			checkYAMLFields(value, inlineMap.Elem(), errs)
+		default:
+			*errs = append(*errs, fmt.Sprintf("line %d: field %s not found in type %s", key.Line, key.Value, typ))
+		}
+	}
+}
+
+func checkYAMLMerge(node *yaml.Node, typ reflect.Type, errs *[]string) {
+	for node.Kind == yaml.AliasNode && node.Alias != nil {
+		node = node.Alias
+	}
+
+	if node.Kind == yaml.SequenceNode {
+		for _, item := range node.Content {
+			checkYAMLMerge(item, typ, errs)
+		}
+
+		return
+	}
+
+	checkYAMLFields(node, typ, errs)
+}
+
+// yamlFields returns types of struct fields by their keys and the type of
+// ",inline" map if any. It follows the rules of yaml.v3.
+func yamlFields(typ reflect.Type) (map[string]reflect.Type, reflect.Type) {
+	fields := make(map[string]reflect.Type, typ.NumField())
+
+	var inlineMap reflect.Type
+
+	for i := range typ.NumField() {
+		field := typ.Field(i)
+		if !field.IsExported() && !field.Anonymous {
+			continue
+		}
+
+		tag := field.Tag.Get("yaml")
+		if tag == "" && !strings.Contains(string(field.Tag), ":") {
+			tag = string(field.Tag)
+		}
+
+		if tag == "-" {
+			continue
+		}
+
+		name, flags, _ := strings.Cut(tag, ",")
+		if slices.Contains(strings.Split(flags, ","), "inline") {
+			inner := field.Type
+			for inner.Kind() == reflect.Pointer {
+				inner = inner.Elem()
+			}
+
+			switch {
+			case inner.Kind() == reflect.Map:
+				inlineMap = inner
+			case inner.Kind() == reflect.Struct && !reflect.PointerTo(inner).Implements(reflect.TypeFor[yaml.Unmarshaler]()):
+				innerFields, innerMap := yamlFields(inner)
+				maps.Copy(fields, innerFields)
+
+				if innerMap != nil {
+					inlineMap = innerMap
+				}
+			}
+
+			continue
+		}
+
+		if name == "" {
+			name = strings.ToLower(field.Name)
+		}
+
+		fields[name] = field.Type
+	}
+
+	return fields, inlineMap
 }
@@ -1,6 +1,7 @@
 package optional
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -122,6 +123,56 @@ func TestYAMLUnmarshal(t *testing.T) {
 			yaml: "v: null",
 			exp:  payload{V: Empty[string]()},
 		},
+		{
+			name: "tilde",
+			yaml: "v: ~",
+			exp:  payload{V: Empty[string]()},
+		},
+		{
+			name: "null_title_case",
+			yaml: "v: Null",
+			exp:  payload{V: Empty[string]()},
+		},
+		{
+			name: "null_upper_case",
+			yaml: "v: NULL",
+			exp:  payload{V: Empty[string]()},
+		},
+		{
+			name: "empty_value",
+			yaml: "v:",
+			exp:  payload{V: Empty[string]()},
+		},
+		{
+			name: "explicit_null_tag",
+			yaml: "v: !!null",
+			exp:  payload{V: Empty[string]()},
+		},
+		{
+			name: "alias_to_null",
+			yaml: "a: &n ~\nv: *n",
+			exp:  payload{V: Empty[string]()},
+		},
+		{
+			name: "double_quoted_null",
+			yaml: "v: \"null\"",
+			exp:  payload{V: New("null")},
+		},
+		{
+			name: "single_quoted_null",
+			yaml: "v: 'null'",
+			exp:  payload{V: New("null")},
+		},
+		{
+			name: "quoted_tilde",
+			yaml: "v: \"~\"",
+			exp:  payload{V: New("~")},
+		},
+		{
+			name: "explicit_str_tag",
+			yaml: "v: !!str null",
+			exp:  payload{V: New("null")},
+		},
 		{
 			name: "missing_field",
 			yaml: "other: value",
@@ -223,6 +274,203 @@ func TestYAMLRoundtrip(t *testing.T) {
 	assert.Equal(t, original, restored)
 }
 
+func TestYAMLUnmarshalNullInt(t *testing.T) {
+	t.Parallel()
+
+	type payload struct {
+		V Val[int] `yaml:"v"`
+	}
+
+	tests := []struct {
+		name string
+		yaml string
+		exp  payload
+	}{
+		{name: "null", yaml: "v: null", exp: payload{V: Empty[int]()}},
+		{name: "null_title_case", yaml: "v: Null", exp: payload{V: Empty[int]()}},
+		{name: "null_upper_case", yaml: "v: NULL", exp: payload{V: Empty[int]()}},
+		{name: "tilde", yaml: "v: ~", exp: payload{V: Empty[int]()}},
+		{name: "empty_value", yaml: "v:", exp: payload{V: Empty[int]()}},
+		{name: "explicit_null_tag", yaml: "v: !!null ''", exp: payload{V: Empty[int]()}},
+		{name: "zero", yaml: "v: 0", exp: payload{V: New(0)}},
+		{name: "value", yaml: "v: 42", exp: payload{V: New(42)}},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var val payload
+			err := yaml.Unmarshal([]byte(tt.yaml), &val)
+			require.NoError(t, err)
+			assert.Equal(t, tt.exp, val)
+		})
+	}
+}
+
+func TestYAMLUnmarshalNode(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		node *yaml.Node
+		exp  Val[string]
+	}{
+		{
+			name: "zero_node",
+			node: &yaml.Node{},
+			exp:  Empty[string](),
+		},
+		{
+			name: "null_tag",
+			node: &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null", Value: "~"},
+			exp:  Empty[string](),
+		},
+		{
+			name: "resolved_null",
+			node: &yaml.Node{Kind: yaml.ScalarNode, Value: "NULL"},
+			exp:  Empty[string](),
+		},
+		{
+			name: "quoted_null",
+			node: &yaml.Node{Kind: yaml.ScalarNode, Style: yaml.DoubleQuotedStyle, Value: "null"},
+			exp:  New("null"),
+		},
+		{
+			name: "str_tag",
+			node: &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: "null"},
+			exp:  New("null"),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			val := New("stale")
+			require.NoError(t, val.UnmarshalYAML(tt.node))
+			assert.Equal(t, tt.exp, val)
+		})
+	}
+}
+
+// TestYAMLDecodeOptions changes global options, so it must not run in parallel.
+func TestYAMLDecodeOptions(t *testing.T) { //nolint:paralleltest
+	type Inner struct {
+		Name string `yaml:"name"`
+	}
+
+	type payload struct {
+		Inner Val[Inner] `yaml:"inner"`
+	}
+
+	const input = "inner:\n  name: test\n  unknown: 1\n"
+
+	defer SetYAMLDecodeOptions(GetYAMLDecodeOptions())
+
+	t.Run("default", func(t *testing.T) {
+		SetYAMLDecodeOptions(YAMLDecodeOptions{})
+
+		var val payload
+		require.NoError(t, yaml.Unmarshal([]byte(input), &val))
+		assert.Equal(t, New(Inner{Name: "test"}), val.Inner)
+	})
+
+	t.Run("known_fields", func(t *testing.T) {
+		SetYAMLDecodeOptions(YAMLDecodeOptions{KnownFields: true})
+
+		var val payload
+		err := yaml.Unmarshal([]byte(input), &val)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "field unknown not found")
+
+		dec := yaml.NewDecoder(strings.NewReader("inner:\n  name: test\n"))
+		dec.KnownFields(true)
+		require.NoError(t, dec.Decode(&val))
+		assert.Equal(t, New(Inner{Name: "test"}), val.Inner)
+
+		var empty payload
+		require.NoError(t, yaml.Unmarshal([]byte("inner: ~"), &empty))
+		assert.Equal(t, Empty[Inner](), empty.Inner)
+	})
+
+	t.Run("known_fields_line", func(t *testing.T) {
+		SetYAMLDecodeOptions(YAMLDecodeOptions{KnownFields: true})
+
+		var val payload
+		err := yaml.Unmarshal([]byte("# comment\n\ninner:\n  name: test\n  unknown: 1\n"), &val)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "line 5: field unknown not found in type optional.Inner")
+
+		err = yaml.Unmarshal([]byte("inner:\n  name: [1]\n  unknown: 1\n"), &val)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "line 2: cannot unmarshal")
+		assert.Contains(t, err.Error(), "line 3: field unknown not found")
+	})
+
+	t.Run("known_fields_alias", func(t *testing.T) {
+		SetYAMLDecodeOptions(YAMLDecodeOptions{KnownFields: true})
+
+		type Tagged struct {
+			Name string   `yaml:"name"`
+			Tags []string `yaml:"tags"`
+		}
+
+		type doc struct {
+			Base  []string    `yaml:"base"`
+			Other Tagged      `yaml:"other"`
+			Inner Val[Tagged] `yaml:"inner"`
+		}
+
+		var val doc
+		require.NoError(t, yaml.Unmarshal([]byte("base: &b [x, y]\ninner: {name: n, tags: *b}\n"), &val))
+		assert.Equal(t, New(Tagged{Name: "n", Tags: []string{"x", "y"}}), val.Inner)
+
+		input := "other: &o {name: n}\ninner:\n  <<: *o\n  tags: [x]\n"
+		require.NoError(t, yaml.Unmarshal([]byte(input), &val))
+		assert.Equal(t, New(Tagged{Name: "n", Tags: []string{"x"}}), val.Inner)
+
+		input = "base: &b {unknown: 1}\ninner:\n  name: n\n  <<: *b\n"
+		err := yaml.Unmarshal([]byte(input), &val)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "line 1: field unknown not found")
+	})
+
+	t.Run("known_fields_inline", func(t *testing.T) {
+		SetYAMLDecodeOptions(YAMLDecodeOptions{KnownFields: true})
+
+		type Base struct {
+			ID int `yaml:"id"`
+		}
+
+		type Item struct {
+			Base  `yaml:",inline"`
+			Name  string            `yaml:"name"`
+			Items []Base            `yaml:"items"`
+			Extra map[string]string `yaml:",inline"`
+		}
+
+		type doc struct {
+			Item Val[Item]   `yaml:"item"`
+			List Val[[]Base] `yaml:"list"`
+		}
+
+		var val doc
+		require.NoError(t, yaml.Unmarshal([]byte("item: {id: 1, name: n, other: x}\n"), &val))
+		assert.Equal(t, New(Item{Base: Base{ID: 1}, Name: "n", Extra: map[string]string{"other": "x"}}), val.Item)
+
+		err := yaml.Unmarshal([]byte("item: {items: [{id: 1, bad: 2}]}\n"), &val)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "line 1: field bad not found in type optional.Base")
+
+		err = yaml.Unmarshal([]byte("list:\n  - {id: 1}\n  - {nope: 2}\n"), &val)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "line 3: field nope not found in type optional.Base")
+	})
+}
+
 func TestYAMLUnmarshalErrors(t *testing.T) {
 	t.Parallel()
 
@@ -338,3 +586,28 @@ func TestYAMLUnmarshalTypes(t *testing.T) {
 		assert.Equal(t, New(3.14), val.V)
 	})
 }
+
+// ptrYAML implements yaml.Marshaler with pointer receiver.
+type ptrYAML struct {
+	Name string
+}
+
+func (p *ptrYAML) MarshalYAML() (interface{}, error) {
+	return "custom:" + p.Name, nil
+}
+
+func TestYAMLPointerReceiverMarshaler(t *testing.T) {
+	t.Parallel()
+
+	type payload struct {
+		V Val[ptrYAML] `yaml:"v"`
+		E Val[ptrYAML] `yaml:"e"`
+	}
+
+	res, err := yaml.Marshal(payload{
+		V: New(ptrYAML{Name: "a"}),
+		E: Empty[ptrYAML](),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "v: custom:a\ne: null\n", string(res))
+}
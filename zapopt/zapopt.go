@@ -0,0 +1,55 @@
+// Package zapopt logs optional values with go.uber.org/zap.
+//
+//	logger.Info("user updated",
+//		zapopt.Field("nickname", user.Nickname),
+//		zap.Object("user", zapopt.Object(user)),
+//	)
+//
+// Present values are logged as values of T. Empty values are logged as null
+// or dropped according to optional.LogOptions.
+package zapopt
+
+import (
+	"github.com/kazhuravlev/optional"
+	"github.com/kazhuravlev/optional/internal/logfield"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Field returns a zap.Field for optional value. Present value is added like
+// zap.Any does.
+func Field[T any](key string, val optional.Val[T]) zap.Field {
+	res, ok := logfield.Resolve(val)
+	if !ok {
+		return zap.Skip()
+	}
+
+	return field(key, res)
+}
+
+// Object returns zapcore.ObjectMarshaler which logs exported fields of struct
+// val. Optional fields are logged as their values, nested structs as objects.
+// Keys are taken from `json` tags.
+func Object(val any) zapcore.ObjectMarshaler { //nolint:ireturn
+	return object{val: val}
+}
+
+type object struct {
+	val any
+}
+
+func (o object) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	for _, f := range logfield.Fields(o.val) {
+		field(f.Key, f.Value).AddTo(enc)
+	}
+
+	return nil
+}
+
+func field(key string, val any) zap.Field {
+	if _, ok := val.(zapcore.ObjectMarshaler); !ok && logfield.IsObject(val) {
+		return zap.Object(key, Object(val))
+	}
+
+	return zap.Any(key, val)
+}
@@ -0,0 +1,85 @@
+package zapopt_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/kazhuravlev/optional"
+	"github.com/kazhuravlev/optional/zapopt"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+type address struct {
+	City optional.Val[string] `json:"city"`
+}
+
+type user struct {
+	ID       int64                   `json:"id"`
+	Nickname optional.Val[string]    `json:"nickname"`
+	Age      optional.Val[int]       `json:"age,omitempty"`
+	Seen     optional.Val[time.Time] `json:"seen"`
+	Address  optional.Val[address]   `json:"address"`
+	Password string                  `json:"-"`
+	hidden   int
+}
+
+func logJSON(fields ...zap.Field) string {
+	var buf bytes.Buffer
+
+	core := zapcore.NewCore(zapcore.NewJSONEncoder(zapcore.EncoderConfig{ //nolint:exhaustruct
+		MessageKey: "msg",
+		EncodeTime: zapcore.RFC3339TimeEncoder,
+	}), zapcore.AddSync(&buf), zapcore.DebugLevel)
+	zap.New(core).Info("msg", fields...)
+
+	return buf.String()
+}
+
+func TestField(t *testing.T) {
+	t.Parallel()
+
+	assert.JSONEq(t, `{"msg":"msg","a":42,"b":null,"c":"","d":{"city":"Berlin"}}`, logJSON(
+		zapopt.Field("a", optional.New(42)),
+		zapopt.Field("b", optional.Empty[int]()),
+		zapopt.Field("c", optional.New("")),
+		zapopt.Field("d", optional.New(address{City: optional.New("Berlin")})),
+	))
+}
+
+func TestObject(t *testing.T) {
+	t.Parallel()
+
+	seen := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	u := user{
+		ID:       1,
+		Nickname: optional.New("kaz"),
+		Age:      optional.Empty[int](),
+		Seen:     optional.New(seen),
+		Address:  optional.New(address{City: optional.Empty[string]()}),
+		Password: "secret",
+		hidden:   1,
+	}
+
+	assert.JSONEq(t,
+		`{"msg":"msg","user":{"id":1,"nickname":"kaz","age":null,"seen":"2024-01-02T03:04:05Z","address":{"city":null}}}`,
+		logJSON(zap.Object("user", zapopt.Object(u))))
+
+	assert.JSONEq(t,
+		`{"msg":"msg","user":{"id":1,"nickname":"kaz","age":null,"seen":"2024-01-02T03:04:05Z","address":{"city":null}}}`,
+		logJSON(zap.Object("user", zapopt.Object(&u))))
+}
+
+func TestLogOptions(t *testing.T) { //nolint:paralleltest
+	defer optional.SetLogOptions(optional.GetLogOptions())
+
+	optional.SetLogOptions(optional.LogOptions{DropEmpty: true}) //nolint:exhaustruct
+
+	assert.JSONEq(t, `{"msg":"msg","a":42,"user":{"id":0,"nickname":"kaz","address":{}}}`, logJSON(
+		zapopt.Field("a", optional.New(42)),
+		zapopt.Field("b", optional.Empty[int]()),
+		zap.Object("user", zapopt.Object(user{Nickname: optional.New("kaz"), Address: optional.New(address{})})), //nolint:exhaustruct
+	))
+}
@@ -0,0 +1,54 @@
+// Package zerologopt logs optional values with github.com/rs/zerolog.
+//
+//	zerologopt.Add(log.Info(), "nickname", user.Nickname).
+//		Object("user", zerologopt.Object(user)).
+//		Msg("user updated")
+//
+// Present values are logged as values of T. Empty values are logged as null
+// or dropped according to optional.LogOptions.
+package zerologopt
+
+import (
+	"github.com/kazhuravlev/optional"
+	"github.com/kazhuravlev/optional/internal/logfield"
+	"github.com/rs/zerolog"
+)
+
+// Add adds optional value to the event.
+func Add[T any](event *zerolog.Event, key string, val optional.Val[T]) *zerolog.Event {
+	res, ok := logfield.Resolve(val)
+	if !ok {
+		return event
+	}
+
+	return add(event, key, res)
+}
+
+// Object returns zerolog.LogObjectMarshaler which logs exported fields of
+// struct val. Optional fields are logged as their values, nested structs as
+// objects. Keys are taken from `json` tags.
+func Object(val any) zerolog.LogObjectMarshaler { //nolint:ireturn
+	return object{val: val}
+}
+
+type object struct {
+	val any
+}
+
+func (o object) MarshalZerologObject(event *zerolog.Event) {
+	for _, f := range logfield.Fields(o.val) {
+		add(event, f.Key, f.Value)
+	}
+}
+
+func add(event *zerolog.Event, key string, val any) *zerolog.Event {
+	if marshaler, ok := val.(zerolog.LogObjectMarshaler); ok {
+		return event.Object(key, marshaler)
+	}
+
+	if logfield.IsObject(val) {
+		return event.Object(key, Object(val))
+	}
+
+	return event.Interface(key, val)
+}
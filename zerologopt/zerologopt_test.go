@@ -0,0 +1,91 @@
+package zerologopt_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/kazhuravlev/optional"
+	"github.com/kazhuravlev/optional/zerologopt"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+type address struct {
+	City optional.Val[string] `json:"city"`
+}
+
+type user struct {
+	ID       int64                   `json:"id"`
+	Nickname optional.Val[string]    `json:"nickname"`
+	Age      optional.Val[int]       `json:"age,omitempty"`
+	Seen     optional.Val[time.Time] `json:"seen"`
+	Address  optional.Val[address]   `json:"address"`
+	Password string                  `json:"-"`
+	hidden   int
+}
+
+func logJSON(fn func(event *zerolog.Event) *zerolog.Event) string {
+	var buf bytes.Buffer
+
+	logger := zerolog.New(&buf)
+	fn(logger.Info()).Msg("msg")
+
+	return buf.String()
+}
+
+func TestAdd(t *testing.T) {
+	t.Parallel()
+
+	assert.JSONEq(t, `{"level":"info","message":"msg","a":42,"b":null,"c":"","d":{"city":"Berlin"}}`,
+		logJSON(func(event *zerolog.Event) *zerolog.Event {
+			event = zerologopt.Add(event, "a", optional.New(42))
+			event = zerologopt.Add(event, "b", optional.Empty[int]())
+			event = zerologopt.Add(event, "c", optional.New(""))
+
+			return zerologopt.Add(event, "d", optional.New(address{City: optional.New("Berlin")}))
+		}))
+}
+
+func TestObject(t *testing.T) {
+	t.Parallel()
+
+	seen := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	u := user{
+		ID:       1,
+		Nickname: optional.New("kaz"),
+		Age:      optional.Empty[int](),
+		Seen:     optional.New(seen),
+		Address:  optional.New(address{City: optional.Empty[string]()}),
+		Password: "secret",
+		hidden:   1,
+	}
+
+	exp := `{"level":"info","message":"msg","user":` +
+		`{"id":1,"nickname":"kaz","age":null,"seen":"2024-01-02T03:04:05Z","address":{"city":null}}}`
+
+	assert.JSONEq(t, exp, logJSON(func(event *zerolog.Event) *zerolog.Event {
+		return event.Object("user", zerologopt.Object(u))
+	}))
+
+	assert.JSONEq(t, exp, logJSON(func(event *zerolog.Event) *zerolog.Event {
+		return event.Object("user", zerologopt.Object(&u))
+	}))
+}
+
+func TestLogOptions(t *testing.T) { //nolint:paralleltest
+	defer optional.SetLogOptions(optional.GetLogOptions())
+
+	optional.SetLogOptions(optional.LogOptions{DropEmpty: true}) //nolint:exhaustruct
+
+	assert.JSONEq(t, `{"level":"info","message":"msg","a":42,"user":{"id":0,"nickname":"kaz","address":{}}}`,
+		logJSON(func(event *zerolog.Event) *zerolog.Event {
+			event = zerologopt.Add(event, "a", optional.New(42))
+			event = zerologopt.Add(event, "b", optional.Empty[int]())
+
+			return event.Object("user", zerologopt.Object(user{ //nolint:exhaustruct
+				Nickname: optional.New("kaz"),
+				Address:  optional.New(address{}), //nolint:exhaustruct
+			}))
+		}))
+}